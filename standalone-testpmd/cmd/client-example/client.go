@@ -4,14 +4,23 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"log"
+	"os"
+	"os/signal"
+	"sort"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
 	pb "github.com/redhat-nfvpe/container-perf-tools/standalone-testpmd/rpc"
+	pbv2 "github.com/redhat-nfvpe/container-perf-tools/standalone-testpmd/rpc/v2"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 )
 
 type macArray []string
@@ -25,10 +34,123 @@ func (p *macArray) Set(value string) error {
 	return nil
 }
 
+var fwdEngines = map[string]pb.FwdEngine{
+	"io":       pb.FwdEngine_FWD_IO,
+	"mac":      pb.FwdEngine_FWD_MAC,
+	"macswap":  pb.FwdEngine_FWD_MACSWAP,
+	"flowgen":  pb.FwdEngine_FWD_FLOWGEN,
+	"rxonly":   pb.FwdEngine_FWD_RXONLY,
+	"txonly":   pb.FwdEngine_FWD_TXONLY,
+	"csum":     pb.FwdEngine_FWD_CSUM,
+	"icmpecho": pb.FwdEngine_FWD_ICMPECHO,
+	"5tswap":   pb.FwdEngine_FWD_5TUPLE_SWAP,
+	"noisy":    pb.FwdEngine_FWD_NOISY,
+}
+
+func fwdModeNames() []string {
+	var names []string
+	for name := range fwdEngines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func parsePeerMacs(peerMacs macArray) []*pb.PeerMac {
+	var mPeer []*pb.PeerMac
+	for _, v := range peerMacs {
+		s := strings.Split(v, ",")
+		if len(s) != 2 {
+			log.Fatalf("illegal peer-mac format: %s", v)
+		}
+		p := &pb.PeerMac{}
+		i, err := strconv.Atoi(s[0])
+		if err != nil {
+			log.Fatalf("illegal port number in peer-mac: %s", v)
+		}
+		p.PortNum = int32(i)
+		p.MacAddress = s[1]
+		mPeer = append(mPeer, p)
+	}
+	return mPeer
+}
+
+func printFwdStats(name string, p *pb.PortFwdStats) {
+	fmt.Printf("%s: rx-packets: %d, rx-dropped: %d, rx-total: %d, tx-packets: %d, tx-dropped: %d, tx-total: %d, "+
+		"bad-ipcsum: %d, bad-l4csum: %d, bad-outer-l4csum: %d\n", name, p.RxPackets, p.RxDropped, p.RxTotal,
+		p.TxPackets, p.TxDropped, p.TxTotal, p.RxBadIpCsum, p.RxBadL4Csum, p.RxBadOuterL4Csum)
+}
+
+// fatalResponse prints the status code of a failed call and the testpmd command and output
+// the server attached, then exits
+func fatalResponse(err error) {
+	st := status.Convert(err)
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			log.Printf("%s, command %q:\n%s", info.Reason, info.Metadata["command"], info.Metadata["output"])
+		}
+	}
+	log.Fatalf("could not get response: %s: %s", st.Code(), st.Message())
+}
+
+func printLcore(role string, l *pb.Lcore) {
+	fmt.Printf("%s lcore %d: numa node %d, local: %v, siblings: %v\n", role, l.Id, l.NumaNode, l.Local, l.Siblings)
+}
+
+func printPortStats(name string, p *pbv2.PortStats) {
+	fmt.Printf("%s: rx-packets: %d, rx-dropped: %d, rx-total: %d, tx-packets: %d, tx-dropped: %d, tx-total: %d, "+
+		"bad-ipcsum: %d, bad-l4csum: %d, bad-outer-l4csum: %d\n", name, p.RxPackets, p.RxDropped, p.RxTotal,
+		p.TxPackets, p.TxDropped, p.TxTotal, p.RxBadIpCsum, p.RxBadL4Csum, p.RxBadOuterL4Csum)
+}
+
+func printLcoreV2(role string, l *pbv2.Lcore) {
+	fmt.Printf("%s lcore %d: numa node %d, local: %v, siblings: %v\n", role, l.Id, l.NumaNode, l.Local, l.Siblings)
+}
+
+func printPortConfig(p *pbv2.PortConfig) {
+	fmt.Printf("rxq: %d, txq: %d, rxd: %d, txd: %d, mtu: %d, mbuf size: %d\n",
+		p.RxQueues, p.TxQueues, p.RxRingSize, p.TxRingSize, p.Mtu, p.MbufSize)
+}
+
+func printStatus(r *pbv2.Status) {
+	fmt.Printf("mode: %s, running: %v\n", r.Mode, r.Running)
+	uptime, _ := ptypes.Duration(r.Uptime)
+	fmt.Printf("pid: %d, uptime: %v, file-prefix: %s\n", r.Pid, uptime.Round(time.Second), r.FilePrefix)
+	fmt.Printf("%s: DPDK %s, options of release %s\n", r.TestpmdPath, r.DpdkVersion, r.DpdkRelease)
+	fmt.Printf("cmdline: %s\n", r.Cmdline)
+	if r.MainLcore != nil {
+		fmt.Printf("core policy: %s\n", r.CorePolicy)
+		printLcoreV2("main", r.MainLcore)
+	}
+	for _, l := range r.PmdLcores {
+		printLcoreV2("pmd", l)
+	}
+	if r.PortConfig != nil {
+		printPortConfig(r.PortConfig)
+	}
+	for _, p := range r.Ports {
+		if p.Driver == "" {
+			fmt.Printf("port %d: %s\n", p.PortId, p.Name)
+			continue
+		}
+		fmt.Printf("port %d: %s, driver %s, previous driver %q", p.PortId, p.Name, p.Driver, p.PreviousDriver)
+		if p.Netdev != "" {
+			fmt.Printf(", netdev %s", p.Netdev)
+		}
+		fmt.Println()
+	}
+}
+
 func main() {
 	grpcPort := flag.Int("grpc-port", 9000, "grpc port")
 	serverIP := flag.String("server", "127.0.0.1", "testpmd server")
-	pci := flag.String("pci", "0000:86:00.0", "pci address to get mac or port info from")
+	pci := flag.String("pci", "0000:86:00.0", "pci address or vdev name to get mac or port info from")
+	txPkts := flag.String("txpkts", "", "txonly/flowgen packet segment lengths, e.g. 64 or 64,128")
+	burst := flag.Int("burst", 0, "txonly/flowgen packets per burst")
+	interval := flag.Int("interval", 1000, "throughput sampling interval in milliseconds")
+	queues := flag.Int("queues", 0, "configure-ports: rx and tx queues per port, unchanged if 0")
+	ringSize := flag.Int("ring-size", 0, "configure-ports: rx and tx ring size, unchanged if 0")
+	mtu := flag.Int("mtu", 0, "configure-ports: mtu of the ports, unchanged if 0")
 	var peerMacs macArray
 	flag.Var(&peerMacs, "peer-mac", "format: <port number>,<mac>, can specify multiple times")
 	flag.Parse()
@@ -38,6 +160,7 @@ func main() {
 	if len(cmdArgs) > 0 {
 		cmd = cmdArgs[0]
 	}
+	mPeer := parsePeerMacs(peerMacs)
 
 	grpcAddress := fmt.Sprintf("%s:%d", *serverIP, *grpcPort)
 
@@ -48,6 +171,7 @@ func main() {
 	}
 	defer conn.Close()
 	c := pb.NewTestpmdClient(conn)
+	c2 := pbv2.NewTestpmdClient(conn)
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
@@ -56,13 +180,13 @@ func main() {
 	case "get-mac":
 		r, err := c.GetMacAddress(ctx, &pb.Pci{PciAddress: *pci})
 		if err != nil {
-			log.Fatalf("could not get response: %v", err)
+			fatalResponse(err)
 		}
 		log.Printf("%s mac address: %s", *pci, r.MacAddress)
 	case "io":
 		r, err := c.IoMode(ctx, &empty.Empty{})
 		if err != nil {
-			log.Fatalf("could not get response: %v", err)
+			fatalResponse(err)
 		}
 		if r.Success {
 			log.Printf("io mode started\n")
@@ -70,34 +194,52 @@ func main() {
 			log.Fatalf("Failed to start io fwd")
 		}
 	case "mac":
-		var mPeer []*pb.PeerMac
-		for _, v := range peerMacs {
-			s := strings.Split(v, ",")
-			if len(s) != 2 {
-				log.Fatalf("illegal peer-mac format: %s", v)
-			}
-			p := &pb.PeerMac{}
-			i, err := strconv.Atoi(s[0])
-			if err != nil {
-				log.Fatalf("illegal port number in peer-mac: %s", v)
-			}
-			p.PortNum = int32(i)
-			p.MacAddress = s[1]
-			mPeer = append(mPeer, p)
-		}
 		r, err := c.MacMode(ctx, &pb.PeerMacs{PeerMac: mPeer})
 		if err != nil {
-			log.Fatalf("could not get response: %v", err)
+			fatalResponse(err)
 		}
 		if r.Success {
 			log.Printf("mac mode started\n")
 		} else {
 			log.Fatalf("Failed to start mac fwd")
 		}
+	case "fwd":
+		if len(cmdArgs) < 2 {
+			log.Fatalf("usage: fwd <%s>", strings.Join(fwdModeNames(), "|"))
+		}
+		engine, ok := fwdEngines[cmdArgs[1]]
+		if !ok {
+			log.Fatalf("unknown forwarding mode %s", cmdArgs[1])
+		}
+		mode := &pb.ForwardingMode{Engine: engine}
+		if *txPkts != "" || *burst != 0 {
+			params := &pb.TxPacketParams{Burst: uint32(*burst)}
+			if *txPkts != "" {
+				for _, v := range strings.Split(*txPkts, ",") {
+					l, err := strconv.Atoi(v)
+					if err != nil {
+						log.Fatalf("illegal txpkts: %s", *txPkts)
+					}
+					params.SegmentLengths = append(params.SegmentLengths, uint32(l))
+				}
+			}
+			mode.Params = &pb.ForwardingMode_TxPacket{TxPacket: params}
+		} else if len(mPeer) > 0 {
+			mode.Params = &pb.ForwardingMode_PeerMacs{PeerMacs: &pb.PeerMacs{PeerMac: mPeer}}
+		}
+		r, err := c.SetForwardingMode(ctx, mode)
+		if err != nil {
+			fatalResponse(err)
+		}
+		if r.Success {
+			log.Printf("%s mode started\n", cmdArgs[1])
+		} else {
+			log.Fatalf("Failed to start %s fwd", cmdArgs[1])
+		}
 	case "icmp":
 		r, err := c.IcmpMode(ctx, &empty.Empty{})
 		if err != nil {
-			log.Fatalf("could not get response: %v", err)
+			fatalResponse(err)
 		}
 		if r.Success {
 			log.Printf("icmp mode started\n")
@@ -107,7 +249,7 @@ func main() {
 	case "ports":
 		r, err := c.ListPorts(ctx, &empty.Empty{})
 		if err != nil {
-			log.Fatalf("could not get response: %v", err)
+			fatalResponse(err)
 		}
 		for _, port := range r.PortInfo {
 			fmt.Printf("port number: %d, mac: %s, pci: %s\n", port.PortNum, port.MacAddress, port.PciAddress)
@@ -115,22 +257,124 @@ func main() {
 	case "port":
 		r, err := c.GetPortInfo(ctx, &pb.Pci{PciAddress: *pci})
 		if err != nil {
-			log.Fatalf("could not get response: %v", err)
+			fatalResponse(err)
 		}
 		fmt.Printf("portNum: %d, mac: %s, pci: %s\n", r.PortNum, r.MacAddress, r.PciAddress)
 	case "fwd-info":
 		r, err := c.GetFwdInfo(ctx, &empty.Empty{})
 		if err != nil {
-			log.Fatalf("could not get response: %v", err)
+			fatalResponse(err)
 		}
 		fmt.Printf("%s\n", r.FwdInfoStr)
+	case "fwd-stats":
+		r, err := c.GetFwdStats(ctx, &empty.Empty{})
+		if err != nil {
+			fatalResponse(err)
+		}
+		for _, p := range r.PortStats {
+			printFwdStats(fmt.Sprintf("port %d", p.PortNum), p)
+		}
+		if r.Accumulated != nil {
+			printFwdStats("all ports", r.Accumulated)
+		}
+	case "throughput":
+		// stream until interrupted, the 1 second ctx above is too short for this
+		sctx, scancel := context.WithCancel(context.Background())
+		defer scancel()
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-sigs
+			scancel()
+		}()
+		stream, err := c.StreamThroughput(sctx, &pb.ThroughputRequest{IntervalMs: uint32(*interval)})
+		if err != nil {
+			fatalResponse(err)
+		}
+		for {
+			r, err := stream.Recv()
+			if err == io.EOF || sctx.Err() != nil {
+				break
+			}
+			if err != nil {
+				fatalResponse(err)
+			}
+			for _, p := range r.PortThroughput {
+				fmt.Printf("port %d: rx-pps: %.0f, tx-pps: %.0f, rx-bps: %.0f, tx-bps: %.0f, rx-drop-pps: %.0f, tx-error-pps: %.0f\n",
+					p.PortNum, p.RxPps, p.TxPps, p.RxBps, p.TxBps, p.RxDropPps, p.TxErrorPps)
+			}
+		}
 	case "clear-fwd-info":
 		_, err := c.ClearFwdInfo(ctx, &empty.Empty{})
 		if err != nil {
-			log.Fatalf("could not get response: %v", err)
+			fatalResponse(err)
 		}
 		fmt.Printf("port forwarding info cleared\n")
+	case "core-plan":
+		r, err := c.GetCorePlan(ctx, &empty.Empty{})
+		if err != nil {
+			fatalResponse(err)
+		}
+		fmt.Printf("policy: %s, port numa nodes: %v\n", r.Policy, r.PortNumaNodes)
+		printLcore("main", r.MainLcore)
+		for _, l := range r.PmdLcores {
+			printLcore("pmd", l)
+		}
+	case "fwd-config":
+		r, err := c.GetForwardingConfig(ctx, &empty.Empty{})
+		if err != nil {
+			fatalResponse(err)
+		}
+		fmt.Printf("mode: %s, ports: %d, cores: %d, streams: %d\n", r.Mode, r.NumPorts, r.NumCores, r.NumStreams)
+		for _, s := range r.FwdStreams {
+			fmt.Printf("lcore %d (socket %d): rx port %d queue %d -> tx port %d queue %d, peer %s\n",
+				s.Lcore, s.LcoreSocket, s.RxPort, s.RxQueue, s.TxPort, s.TxQueue, s.PeerMac)
+		}
+	case "dpdk-version":
+		r, err := c.GetDpdkVersion(ctx, &empty.Empty{})
+		if err != nil {
+			fatalResponse(err)
+		}
+		fmt.Printf("%s: DPDK %s, options of release %s\n", r.TestpmdPath, r.Version, r.Release)
+	case "start":
+		r, err := c2.StartForwarding(ctx, &pbv2.StartForwardingRequest{})
+		if err != nil {
+			fatalResponse(err)
+		}
+		fmt.Printf("%s forwarding started\n", r.Mode)
+	case "stop":
+		r, err := c2.StopForwarding(ctx, &pbv2.StopForwardingRequest{})
+		if err != nil {
+			fatalResponse(err)
+		}
+		for _, p := range r.Ports {
+			printPortStats(fmt.Sprintf("port %d", p.PortId), p)
+		}
+		if r.Total != nil {
+			printPortStats("all ports", r.Total)
+		}
+	case "configure-ports":
+		// the ports are stopped and started, which waits for the links
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		r, err := c2.ConfigurePorts(ctx, &pbv2.PortConfig{
+			RxQueues:   uint32(*queues),
+			TxQueues:   uint32(*queues),
+			RxRingSize: uint32(*ringSize),
+			TxRingSize: uint32(*ringSize),
+			Mtu:        uint32(*mtu),
+		})
+		if err != nil {
+			fatalResponse(err)
+		}
+		printPortConfig(r)
+	case "status":
+		r, err := c2.GetStatus(ctx, &pbv2.GetStatusRequest{})
+		if err != nil {
+			fatalResponse(err)
+		}
+		printStatus(r)
 	default:
-		fmt.Println("supported commands: get-mac ports port io mac icmp fwd-info clear-fwd-info")
+		fmt.Println("supported commands: get-mac ports port io mac icmp fwd fwd-info fwd-stats throughput clear-fwd-info core-plan fwd-config dpdk-version status start stop configure-ports")
 	}
 }
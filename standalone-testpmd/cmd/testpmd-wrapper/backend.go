@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	expect "github.com/google/goexpect"
+	pb "github.com/redhat-nfvpe/container-perf-tools/standalone-testpmd/rpc"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpuset"
+)
+
+// testpmdOps are the testpmd operations the grpc server and the metrics are built on
+type testpmdOps interface {
+	getState() (string, bool, time.Time)
+	getRunInfo() runInfo
+	getCorePlan() *corePlan
+	getPorts() []string
+	getDpdkVersion() (string, *dpdkRelease, string)
+	setFwdMode(ctx context.Context, mode string) error
+	setFwdModeWith(ctx context.Context, mode string, setupCmds []string) error
+	startFwd(ctx context.Context) error
+	configurePorts(ctx context.Context, want portSettings) (portSettings, error)
+	stopFwd(ctx context.Context) (*pb.FwdStats, error)
+	icmpMode(ctx context.Context) error
+	ioMode(ctx context.Context) error
+	macMode(ctx context.Context) error
+	getMacAddress(ctx context.Context, pci string) (string, error)
+	setPeerMac(ctx context.Context, portNum int32, peerMac string) error
+	listPorts(ctx context.Context) (string, error)
+	getPortInfo(ctx context.Context, pci string) (string, error)
+	getFwdInfo(ctx context.Context) (string, error)
+	getFwdStats(ctx context.Context) (*pb.FwdStats, error)
+	getFwdConfig(ctx context.Context) (*pb.ForwardingConfig, error)
+	getPortCounters(ctx context.Context) ([]*portCounters, error)
+	clearFwdInfo(ctx context.Context) (string, error)
+}
+
+// session is the interactive testpmd prompt, *expect.GExpect for a real testpmd
+type session interface {
+	Send(string) error
+	Expect(*regexp.Regexp, time.Duration) (string, []string, error)
+	Close() error
+}
+
+// backend is where testpmd runs
+type backend interface {
+	// spawn starts testpmd with the given command line
+	spawn(cmd string, timeout time.Duration) (session, error)
+	// pid returns the pid of the testpmd started with filePrefix, 0 if not found
+	pid(filePrefix string) int
+	// probe finds the testpmd binary and returns its path and DPDK version
+	probe(testpmdPath string) (string, string, error)
+	// bus returns the pci bus holding the ports
+	bus() *pciBus
+	// hugepageSizeKB returns the size of the huge pages testpmd allocates from, 0 if unknown
+	hugepageSizeKB() uint64
+	// cpus returns the cpus testpmd may use
+	cpus() cpuset.CPUSet
+	// close releases whatever the backend set up
+	close() error
+}
+
+// hostBackend runs the testpmd binary on the host
+type hostBackend struct {
+	pci *pciBus
+}
+
+func (hostBackend) spawn(cmd string, timeout time.Duration) (session, error) {
+	e, _, err := expect.Spawn(cmd, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// pid looks for the process with the file prefix on its command line, goexpect doesn't tell the pid
+func (hostBackend) pid(filePrefix string) int {
+	cmdlines, _ := filepath.Glob("/proc/[0-9]*/cmdline")
+	for _, c := range cmdlines {
+		out, err := ioutil.ReadFile(c)
+		if err != nil {
+			continue
+		}
+		args := strings.Split(string(out), "\x00")
+		for i := 0; i+1 < len(args); i++ {
+			if args[i] == "--file-prefix" && args[i+1] == filePrefix {
+				pid, _ := strconv.Atoi(filepath.Base(filepath.Dir(c)))
+				return pid
+			}
+		}
+	}
+	return 0
+}
+
+func (hostBackend) probe(testpmdPath string) (string, string, error) {
+	path, err := resolveTestpmd(testpmdPath)
+	if err != nil {
+		return "", "", err
+	}
+	version, err := probeDpdkVersion(path)
+	return path, version, err
+}
+
+func (h hostBackend) bus() *pciBus {
+	return h.pci
+}
+
+func (hostBackend) hugepageSizeKB() uint64 {
+	return hostHugepageSizeKB()
+}
+
+func (hostBackend) cpus() cpuset.CPUSet {
+	return getProcCpuset()
+}
+
+func (hostBackend) close() error {
+	return nil
+}
+
+// newBackend returns the named backend, sysfsRoot is where the host pci devices are
+func newBackend(name string, sysfsRoot string, pci pciArray, pf string) (backend, error) {
+	switch name {
+	case "host":
+		return hostBackend{pci: newHostPciBus(sysfsRoot)}, nil
+	case "sim":
+		return newSimBackend(pci, pf)
+	}
+	return nil, fmt.Errorf("unknown backend %s", name)
+}
@@ -0,0 +1,395 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/redhat-nfvpe/container-perf-tools/standalone-testpmd/rpc"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpuset"
+	"sigs.k8s.io/yaml"
+)
+
+// wrapperConfig holds every setting of the wrapper. It is read from the -config file, then the
+// PCIDEVICE environment variables and the flags given on the command line override it.
+type wrapperConfig struct {
+	Pci             []string          `json:"pci,omitempty"`
+	Vdev            []string          `json:"vdev,omitempty"`
+	Queues          int               `json:"queues"`
+	RingSize        int               `json:"ringSize"`
+	MbufSize        int               `json:"mbufSize"`
+	TestpmdPath     string            `json:"testpmdPath"`
+	DpdkVersion     string            `json:"dpdkVersion,omitempty"`
+	DpdkDriver      string            `json:"dpdkDriver"`
+	DriverOverrides map[string]string `json:"driverOverrides,omitempty"`
+	DriverTable     string            `json:"driverTable,omitempty"`
+	VfioNoIommu     bool              `json:"vfioNoIommu,omitempty"`
+	Pf              string            `json:"pf,omitempty"`
+	NumVfs          int               `json:"numVfs,omitempty"`
+	Vfs             string            `json:"vfs,omitempty"`
+	VfMacs          []string          `json:"vfMacs,omitempty"`
+	VfVlans         []string          `json:"vfVlans,omitempty"`
+	VfTrust         bool              `json:"vfTrust"`
+	VfSpoofchk      bool              `json:"vfSpoofchk"`
+	ResetVfs        bool              `json:"resetVfs,omitempty"`
+	EalArgs         []string          `json:"ealArgs,omitempty"`
+	SysfsRoot       string            `json:"sysfsRoot"`
+	Backend         string            `json:"backend"`
+	CorePolicy      string            `json:"corePolicy"`
+	QueueMap        []string          `json:"queueMap,omitempty"`
+	QueueMapFile    string            `json:"queueMapFile,omitempty"`
+	Auto            bool              `json:"auto"`
+	FwdMode         string            `json:"fwdMode,omitempty"`
+	PeerMacs        []string          `json:"peerMacs,omitempty"`
+	ListenAddress   string            `json:"listenAddress,omitempty"`
+	GrpcPort        int               `json:"grpcPort"`
+	MetricsPort     int               `json:"metricsPort"`
+	MetricsCache    duration          `json:"metricsCache"`
+	Journal         string            `json:"journal"`
+
+	// startup options of the noisy engine, 0 for the testpmd default
+	NoisyTxSwBufferSize      int `json:"noisyTxSwBufferSize,omitempty"`
+	NoisyTxSwBufferFlushtime int `json:"noisyTxSwBufferFlushtime,omitempty"`
+	NoisyLkupMemory          int `json:"noisyLkupMemory,omitempty"`
+	NoisyLkupNumWrites       int `json:"noisyLkupNumWrites,omitempty"`
+	NoisyLkupNumReads        int `json:"noisyLkupNumReads,omitempty"`
+	NoisyLkupNumReadsWrites  int `json:"noisyLkupNumReadsWrites,omitempty"`
+}
+
+func defaultConfig() *wrapperConfig {
+	return &wrapperConfig{
+		Queues:       1,
+		RingSize:     2048,
+		MbufSize:     defaultMbufSize,
+		TestpmdPath:  "testpmd",
+		SysfsRoot:    defaultSysfsRoot,
+		Backend:      "host",
+		CorePolicy:   corePolicyLocalPreferred,
+		GrpcPort:     9000,
+		VfSpoofchk:   true,
+		MetricsCache: duration(time.Second),
+		Journal:      defaultJournalPath,
+	}
+}
+
+// duration is a time.Duration written as "1s" in the config file
+type duration time.Duration
+
+func (d duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = duration(v)
+	return nil
+}
+
+func (d *duration) String() string {
+	return time.Duration(*d).String()
+}
+
+func (d *duration) Set(value string) error {
+	v, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*d = duration(v)
+	return nil
+}
+
+// listFlag is a repeatable flag, the first time it is given it replaces the list from the config file
+type listFlag struct {
+	list *[]string
+	set  bool
+}
+
+func (l *listFlag) String() string {
+	if l.list == nil {
+		return ""
+	}
+	return strings.Join(*l.list, " ")
+}
+
+func (l *listFlag) Set(value string) error {
+	if !l.set {
+		*l.list = nil
+		l.set = true
+	}
+	*l.list = append(*l.list, value)
+	return nil
+}
+
+// mapFlag is a repeatable key=value flag, adding to the map from the config file
+type mapFlag struct {
+	m *map[string]string
+}
+
+func (f *mapFlag) String() string {
+	if f.m == nil {
+		return ""
+	}
+	var s []string
+	for k, v := range *f.m {
+		s = append(s, k+"="+v)
+	}
+	sort.Strings(s)
+	return strings.Join(s, " ")
+}
+
+func (f *mapFlag) Set(value string) error {
+	kv := strings.SplitN(value, "=", 2)
+	if len(kv) != 2 {
+		return fmt.Errorf("expect key=value, got %s", value)
+	}
+	if *f.m == nil {
+		*f.m = make(map[string]string)
+	}
+	(*f.m)[kv[0]] = kv[1]
+	return nil
+}
+
+// options that are not settings
+type cliOptions struct {
+	configPath  string
+	printConfig bool
+}
+
+// flagSet binds the flags to c, their defaults are the values of c
+func (c *wrapperConfig) flagSet(opts *cliOptions, handling flag.ErrorHandling) *flag.FlagSet {
+	fs := flag.NewFlagSet(os.Args[0], handling)
+	fs.StringVar(&opts.configPath, "config", "", "yaml or json config file, the flags override it")
+	fs.BoolVar(&opts.printConfig, "print-config", false, "print the effective config as yaml and exit")
+	fs.Var(&listFlag{list: &c.Pci}, "pci", "pci address, can specify multiple times")
+	fs.Var(&listFlag{list: &c.Vdev}, "vdev", "virtual device port, like net_null0 or net_vhost0,iface=/var/run/vhost.sock, can specify multiple times")
+	fs.IntVar(&c.Queues, "queues", c.Queues, "number of rxq/txq")
+	fs.IntVar(&c.RingSize, "ring-size", c.RingSize, "ring size")
+	fs.IntVar(&c.MbufSize, "mbuf-size", c.MbufSize, "mbuf data size in bytes")
+	fs.StringVar(&c.TestpmdPath, "testpmd-path", c.TestpmdPath, "if not in PATH, specify the testpmd location")
+	fs.StringVar(&c.DpdkVersion, "dpdk-version", c.DpdkVersion, "skip the detection and use the options of this DPDK version, e.g. 20.11")
+	fs.StringVar(&c.DpdkDriver, "dpdk-driver", c.DpdkDriver, "dpdk driver of all ports, the one of the driver table if empty")
+	fs.Var(&mapFlag{m: &c.DriverOverrides}, "driver-override", "dpdk driver of a port, format: <pci>=<driver>, can specify multiple times")
+	fs.StringVar(&c.DriverTable, "driver-table", c.DriverTable, "yaml or json file of vendor, device, kernel and dpdk drivers, extending the built-in table")
+	fs.BoolVar(&c.VfioNoIommu, "vfio-noiommu", c.VfioNoIommu, "allow vfio-pci without iommu, unsafe, for VMs without vIOMMU")
+	fs.StringVar(&c.Pf, "pf", c.Pf, "pci address of an SR-IOV PF, its VFs are created and used as ports")
+	fs.IntVar(&c.NumVfs, "num-vfs", c.NumVfs, "number of VFs to create on -pf")
+	fs.StringVar(&c.Vfs, "vfs", c.Vfs, "VFs of -pf used as ports, like 0-1,4, all if empty")
+	fs.Var(&listFlag{list: &c.VfMacs}, "vf-mac", "mac of a VF, format: <vf>,<mac>, can specify multiple times")
+	fs.Var(&listFlag{list: &c.VfVlans}, "vf-vlan", "port vlan of a VF, format: <vf>,<vlan>, can specify multiple times")
+	fs.BoolVar(&c.VfTrust, "vf-trust", c.VfTrust, "trust the VFs, to let them change their mac or go promiscuous")
+	fs.BoolVar(&c.VfSpoofchk, "vf-spoofchk", c.VfSpoofchk, "mac spoof check of the VFs")
+	fs.BoolVar(&c.ResetVfs, "reset-vfs", c.ResetVfs, "allow removing the VFs -pf already has to create -num-vfs, their setup is lost")
+	fs.Var(&listFlag{list: &c.EalArgs}, "eal-arg", "extra EAL argument, can specify multiple times")
+	fs.StringVar(&c.SysfsRoot, "sysfs-root", c.SysfsRoot, "where sysfs is mounted")
+	fs.StringVar(&c.Backend, "backend", c.Backend, "where testpmd runs: host, or sim for a simulated testpmd without DPDK or NICs")
+	fs.StringVar(&c.CorePolicy, "core-policy", c.CorePolicy, "where the lcores go: local-strict for the numa nodes of the ports only, local-preferred to use them first, or any")
+	fs.Var(&listFlag{list: &c.QueueMap}, "queue-map", "pin the rx queue of a port to a pmd lcore, format: <port>:<rxq>@<lcore>, can specify multiple times")
+	fs.StringVar(&c.QueueMapFile, "queue-map-file", c.QueueMapFile, "file with a queue mapping per line, instead of -queue-map")
+	fs.BoolVar(&c.Auto, "auto", c.Auto, "auto start in io mode")
+	fs.StringVar(&c.FwdMode, "fwd-mode", c.FwdMode, "forwarding mode to start in, like io or mac")
+	fs.Var(&listFlag{list: &c.PeerMacs}, "peer-mac", "peer mac set at start, format: <port number>,<mac>, can specify multiple times")
+	fs.IntVar(&c.NoisyTxSwBufferSize, "noisy-tx-sw-buffer-size", c.NoisyTxSwBufferSize, "noisy engine: packets buffered in the tx fifo per port")
+	fs.IntVar(&c.NoisyTxSwBufferFlushtime, "noisy-tx-sw-buffer-flushtime", c.NoisyTxSwBufferFlushtime, "noisy engine: ms before the tx fifo is flushed")
+	fs.IntVar(&c.NoisyLkupMemory, "noisy-lkup-memory", c.NoisyLkupMemory, "noisy engine: MB of memory the lookups go to per port")
+	fs.IntVar(&c.NoisyLkupNumWrites, "noisy-lkup-num-writes", c.NoisyLkupNumWrites, "noisy engine: random writes per packet")
+	fs.IntVar(&c.NoisyLkupNumReads, "noisy-lkup-num-reads", c.NoisyLkupNumReads, "noisy engine: random reads per packet")
+	fs.IntVar(&c.NoisyLkupNumReadsWrites, "noisy-lkup-num-reads-writes", c.NoisyLkupNumReadsWrites, "noisy engine: random reads and writes per packet")
+	fs.StringVar(&c.ListenAddress, "listen-address", c.ListenAddress, "address the grpc and metrics servers listen on, all addresses if empty")
+	fs.IntVar(&c.GrpcPort, "grpc-port", c.GrpcPort, "grpc port")
+	fs.IntVar(&c.MetricsPort, "metrics-port", c.MetricsPort, "serve prometheus metrics over http on this port, 0 to disable")
+	fs.Var(&c.MetricsCache, "metrics-cache", "how long scraped testpmd counters are reused")
+	fs.StringVar(&c.Journal, "journal", c.Journal, "file recording the port bindings, to restore them after a crash, empty to disable")
+	return fs
+}
+
+// loadConfig merges the defaults, the config file, the environment and the flags, in this order.
+// It returns the arguments left after the flags.
+func loadConfig(args []string) (*wrapperConfig, *cliOptions, []string, error) {
+	// the config file is needed before the flags are applied, find it with a first parse
+	opts := &cliOptions{}
+	probe := defaultConfig().flagSet(opts, flag.ContinueOnError)
+	probe.SetOutput(ioutil.Discard)
+	probe.Parse(args)
+
+	c := defaultConfig()
+	if opts.configPath != "" {
+		data, err := ioutil.ReadFile(opts.configPath)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if err := yaml.UnmarshalStrict(data, c); err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid config file %s: %v", opts.configPath, err)
+		}
+	}
+	c.applyEnv()
+	opts = &cliOptions{}
+	fs := c.flagSet(opts, flag.ExitOnError)
+	fs.Parse(args)
+	// normalize pci address to start with 0000: prefix
+	for i, p := range c.Pci {
+		c.Pci[i] = normalizePci(p)
+	}
+	if c.Pf != "" {
+		c.Pf = normalizePci(c.Pf)
+	}
+	if len(c.DriverOverrides) > 0 {
+		overrides := make(map[string]string)
+		for p, d := range c.DriverOverrides {
+			overrides[normalizePci(p)] = d
+		}
+		c.DriverOverrides = overrides
+	}
+	return c, opts, fs.Args(), nil
+}
+
+// applyEnv takes the pci addresses from the PCIDEVICE variables the device plugins set
+func (c *wrapperConfig) applyEnv() {
+	var pci []string
+	for _, e := range os.Environ() {
+		pair := strings.SplitN(e, "=", 2)
+		if match, _ := regexp.MatchString("PCIDEVICE", pair[0]); match {
+			pci = append(pci, pair[1])
+		}
+	}
+	if len(pci) > 0 {
+		c.Pci = pci
+	}
+}
+
+func (c *wrapperConfig) print() error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
+
+// queueMap returns the queue mappings of -queue-map or of the -queue-map-file
+func (c *wrapperConfig) queueMap() (queueMapArray, error) {
+	if c.QueueMapFile != "" {
+		if len(c.QueueMap) > 0 {
+			return nil, fmt.Errorf("use either -queue-map or -queue-map-file")
+		}
+		return readQueueMapFile(c.QueueMapFile)
+	}
+	var q queueMapArray
+	for _, s := range c.QueueMap {
+		m, err := parseQueueMapping(s)
+		if err != nil {
+			return nil, err
+		}
+		q = append(q, m)
+	}
+	return q, nil
+}
+
+// noisy returns the startup options of the noisy engine
+func (c *wrapperConfig) noisy() (noisySettings, error) {
+	n := noisySettings{
+		txSwBufferSize:      c.NoisyTxSwBufferSize,
+		txSwBufferFlushtime: c.NoisyTxSwBufferFlushtime,
+		lkupMemory:          c.NoisyLkupMemory,
+		lkupNumWrites:       c.NoisyLkupNumWrites,
+		lkupNumReads:        c.NoisyLkupNumReads,
+		lkupNumReadsWrites:  c.NoisyLkupNumReadsWrites,
+	}
+	for _, o := range n.options() {
+		if o.value < 0 {
+			return n, fmt.Errorf("-%s can't be negative: %d", o.name, o.value)
+		}
+	}
+	return n, nil
+}
+
+// peerMacs parses the "<port number>,<mac>" peer macs
+func (c *wrapperConfig) peerMacs() ([]*pb.PeerMac, error) {
+	var peers []*pb.PeerMac
+	for _, v := range c.PeerMacs {
+		s := strings.Split(v, ",")
+		if len(s) != 2 {
+			return nil, fmt.Errorf("illegal peer-mac format: %s", v)
+		}
+		i, err := strconv.Atoi(s[0])
+		if err != nil {
+			return nil, fmt.Errorf("illegal port number in peer-mac: %s", v)
+		}
+		peers = append(peers, &pb.PeerMac{PortNum: int32(i), MacAddress: s[1]})
+	}
+	return peers, nil
+}
+
+// sriov returns the VF setup of -pf, nil without -pf
+func (c *wrapperConfig) sriov() (*sriovConfig, error) {
+	if c.Pf == "" {
+		return nil, nil
+	}
+	if c.NumVfs <= 0 {
+		return nil, fmt.Errorf("-pf needs -num-vfs")
+	}
+	vfs, err := cpuset.Parse(c.Vfs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VF list %s: %v", c.Vfs, err)
+	}
+	s := &sriovConfig{
+		pf:       c.Pf,
+		numVfs:   c.NumVfs,
+		vfs:      vfs,
+		macs:     make(map[int]net.HardwareAddr),
+		vlans:    make(map[int]int),
+		trust:    c.VfTrust,
+		spoofchk: c.VfSpoofchk,
+		reset:    c.ResetVfs,
+	}
+	for _, v := range c.VfMacs {
+		vf, value, err := splitVfSetting(v)
+		if err != nil {
+			return nil, fmt.Errorf("illegal vf-mac %s: %v", v, err)
+		}
+		mac, err := net.ParseMAC(value)
+		if err != nil {
+			return nil, fmt.Errorf("illegal vf-mac %s: %v", v, err)
+		}
+		s.macs[vf] = mac
+	}
+	for _, v := range c.VfVlans {
+		vf, value, err := splitVfSetting(v)
+		if err != nil {
+			return nil, fmt.Errorf("illegal vf-vlan %s: %v", v, err)
+		}
+		vlan, err := strconv.Atoi(value)
+		if err != nil || vlan < 0 || vlan > 4095 {
+			return nil, fmt.Errorf("illegal vf-vlan %s: vlan must be 0-4095", v)
+		}
+		s.vlans[vf] = vlan
+	}
+	return s, nil
+}
+
+// splitVfSetting splits "<vf>,<value>"
+func splitVfSetting(v string) (int, string, error) {
+	s := strings.SplitN(v, ",", 2)
+	if len(s) != 2 {
+		return 0, "", fmt.Errorf("expect <vf>,<value>")
+	}
+	vf, err := strconv.Atoi(s[0])
+	if err != nil || vf < 0 {
+		return 0, "", fmt.Errorf("invalid VF number %s", s[0])
+	}
+	return vf, s[1], nil
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	pb "github.com/redhat-nfvpe/container-perf-tools/standalone-testpmd/rpc"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpuset"
+)
+
+const (
+	// only cpus on the numa nodes of the ports
+	corePolicyLocalStrict = "local-strict"
+	// cpus on the numa nodes of the ports first, then the others
+	corePolicyLocalPreferred = "local-preferred"
+	// the allowed cpus in order, whatever their numa node
+	corePolicyAny = "any"
+)
+
+var corePolicies = []string{corePolicyLocalStrict, corePolicyLocalPreferred, corePolicyAny}
+
+// corePlan is the lcores testpmd runs on: the main lcore for the prompt and a pmd lcore per queue
+type corePlan struct {
+	policy string
+	// numa nodes of the ports, empty if unknown
+	portNodes []int
+	mainLcore int
+	// in stream order if pinned
+	pmdLcores []int
+	// the pmd lcores come from a queue map
+	pinned bool
+	topo   *cpuTopology
+}
+
+// newCorePlan picks nPmd pmd lcores and a main lcore out of cpus. The pmds go on distinct physical
+// cores as long as there are enough, the main lcore avoids the physical cores of the pmds if it can.
+// If pinned is given, these are the pmd lcores and only the main lcore is picked.
+func newCorePlan(topo *cpuTopology, cpus cpuset.CPUSet, portNodes []int, nPmd int, policy string, pinned []int) (*corePlan, error) {
+	if !containsString(corePolicies, policy) {
+		return nil, fmt.Errorf("unknown core policy %s, expect one of %s", policy, strings.Join(corePolicies, ", "))
+	}
+	if pinned != nil {
+		for _, cpu := range pinned {
+			if !cpus.Contains(cpu) {
+				return nil, fmt.Errorf("mapped lcore %d is not in the allowed cpus %s", cpu, cpus)
+			}
+			if policy == corePolicyLocalStrict && !isLocalCpu(topo, portNodes, cpu) {
+				return nil, fmt.Errorf("mapped lcore %d is on numa node %d, not on a node of the ports", cpu, topo.nodeOf(cpu))
+			}
+		}
+		cpus = cpus.Difference(cpuset.NewCPUSet(pinned...))
+		nPmd = 0
+	}
+	var local, remote []int
+	for _, cpu := range cpus.ToSlice() {
+		if isLocalCpu(topo, portNodes, cpu) {
+			local = append(local, cpu)
+		} else {
+			remote = append(remote, cpu)
+		}
+	}
+	var tiers [][]int
+	switch policy {
+	case corePolicyLocalStrict:
+		tiers = [][]int{local}
+	case corePolicyLocalPreferred:
+		tiers = [][]int{local, remote}
+	case corePolicyAny:
+		tiers = [][]int{cpus.ToSlice()}
+	}
+	// within a tier, one thread of every physical core comes before their siblings
+	var order []int
+	for _, tier := range tiers {
+		taken := cpuset.NewCPUSet()
+		var siblings []int
+		for _, cpu := range tier {
+			if taken.Intersection(topo.siblingsOf(cpu)).IsEmpty() {
+				order = append(order, cpu)
+				taken = taken.Union(cpuset.NewCPUSet(cpu))
+			} else {
+				siblings = append(siblings, cpu)
+			}
+		}
+		order = append(order, siblings...)
+	}
+	if len(order) < nPmd+1 {
+		return nil, fmt.Errorf("insufficient cores: %d pmd lcores and a main lcore needed, only %d of the cpus %s fit core policy %s",
+			nPmd, len(order), cpus, policy)
+	}
+	plan := &corePlan{
+		policy:    policy,
+		portNodes: portNodes,
+		mainLcore: order[nPmd],
+		pmdLcores: order[:nPmd],
+		topo:      topo,
+	}
+	if pinned != nil {
+		plan.pmdLcores = pinned
+		plan.pinned = true
+	}
+	pmdCores := cpuset.NewCPUSet()
+	for _, cpu := range plan.pmdLcores {
+		pmdCores = pmdCores.Union(topo.siblingsOf(cpu))
+	}
+	for _, cpu := range order[nPmd:] {
+		if pmdCores.Intersection(topo.siblingsOf(cpu)).IsEmpty() {
+			plan.mainLcore = cpu
+			break
+		}
+	}
+	return plan, nil
+}
+
+// isLocalCpu tells if the cpu is on a numa node of the ports, unknown nodes are local
+func isLocalCpu(topo *cpuTopology, portNodes []int, cpu int) bool {
+	node := topo.nodeOf(cpu)
+	return len(portNodes) == 0 || node < 0 || containsInt(portNodes, node)
+}
+
+// lcores returns all the lcores of the plan in ascending order
+func (p *corePlan) lcores() []int {
+	lcores := append([]int{p.mainLcore}, p.pmdLcores...)
+	sort.Ints(lcores)
+	return lcores
+}
+
+// sharedPmds returns the pmd lcores that share their physical core with another pmd lcore
+func (p *corePlan) sharedPmds() []int {
+	var shared []int
+	for _, cpu := range p.pmdLcores {
+		for _, other := range p.pmdLcores {
+			if other != cpu && p.topo.siblingsOf(cpu).Contains(other) {
+				shared = append(shared, cpu)
+				break
+			}
+		}
+	}
+	return shared
+}
+
+func (p *corePlan) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "core policy %s, ports on numa nodes %s, main lcore %d (numa %d), pmd lcores",
+		p.policy, intToString(p.portNodes, ","), p.mainLcore, p.topo.nodeOf(p.mainLcore))
+	for _, cpu := range p.pmdLcores {
+		fmt.Fprintf(&b, " %d (numa %d)", cpu, p.topo.nodeOf(cpu))
+	}
+	if p.pinned {
+		b.WriteString(" from the queue map")
+	}
+	if shared := p.sharedPmds(); len(shared) > 0 {
+		fmt.Fprintf(&b, ", pmd lcores %s share physical cores", intToString(shared, ","))
+	}
+	return b.String()
+}
+
+func (p *corePlan) lcoreInfo(cpu int) *pb.Lcore {
+	lcore := &pb.Lcore{
+		Id:       int32(cpu),
+		NumaNode: int32(p.topo.nodeOf(cpu)),
+		Local:    isLocalCpu(p.topo, p.portNodes, cpu),
+	}
+	for _, s := range p.topo.siblingsOf(cpu).ToSlice() {
+		if s != cpu {
+			lcore.Siblings = append(lcore.Siblings, int32(s))
+		}
+	}
+	return lcore
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpuset"
+)
+
+// testTopology has two numa nodes of 4 physical cores with 2 threads each: cpus 0-3 and 8-11 are
+// on node 0, 4-7 and 12-15 on node 1, cpu n and n+8 are siblings
+func testTopology() *cpuTopology {
+	topo := &cpuTopology{node: make(map[int]int), siblings: make(map[int]cpuset.CPUSet)}
+	for cpu := 0; cpu < 16; cpu++ {
+		topo.node[cpu] = cpu % 8 / 4
+		topo.siblings[cpu] = cpuset.NewCPUSet(cpu%8, cpu%8+8)
+	}
+	return topo
+}
+
+func TestNewCorePlan(t *testing.T) {
+	all := cpuset.MustParse("0-15")
+	tests := []struct {
+		name      string
+		cpus      cpuset.CPUSet
+		portNodes []int
+		nPmd      int
+		policy    string
+		pinned    []int
+		wantPmds  []int
+		wantMain  int
+		// pmd lcores sharing a physical core
+		wantShared []int
+		// part of the error, none if empty
+		wantErr string
+	}{
+		{
+			name: "local cores", cpus: all, portNodes: []int{0}, nPmd: 2, policy: corePolicyLocalStrict,
+			wantPmds: []int{0, 1}, wantMain: 2,
+		},
+		{
+			// the main lcore can only share a core with a pmd
+			name: "all local cores", cpus: all, portNodes: []int{0}, nPmd: 4, policy: corePolicyLocalStrict,
+			wantPmds: []int{0, 1, 2, 3}, wantMain: 8,
+		},
+		{
+			name: "node with too few cores", cpus: all, portNodes: []int{0}, nPmd: 8, policy: corePolicyLocalStrict,
+			wantErr: "insufficient cores: 8 pmd lcores and a main lcore needed, only 8",
+		},
+		{
+			name: "remote main lcore", cpus: all, portNodes: []int{0}, nPmd: 8, policy: corePolicyLocalPreferred,
+			wantPmds: []int{0, 1, 2, 3, 8, 9, 10, 11}, wantMain: 4, wantShared: []int{0, 1, 2, 3, 8, 9, 10, 11},
+		},
+		{
+			name: "any node", cpus: all, portNodes: []int{1}, nPmd: 2, policy: corePolicyAny,
+			wantPmds: []int{0, 1}, wantMain: 2,
+		},
+		{
+			name: "ports on both nodes", cpus: cpuset.NewCPUSet(0, 4, 8, 12), portNodes: []int{0, 1}, nPmd: 2,
+			policy: corePolicyLocalStrict, wantPmds: []int{0, 4}, wantMain: 8,
+		},
+		{
+			name: "vdevs only", cpus: all, nPmd: 1, policy: corePolicyLocalStrict,
+			wantPmds: []int{0}, wantMain: 1,
+		},
+		{
+			name: "allowed cpus", cpus: cpuset.NewCPUSet(1, 4, 9), portNodes: []int{0}, nPmd: 1, policy: corePolicyLocalStrict,
+			wantPmds: []int{1}, wantMain: 9,
+		},
+		{
+			name: "pinned", cpus: all, portNodes: []int{0}, policy: corePolicyLocalStrict, pinned: []int{3, 2},
+			wantPmds: []int{3, 2}, wantMain: 0,
+		},
+		{
+			name: "pinned on siblings", cpus: all, portNodes: []int{0}, policy: corePolicyLocalStrict, pinned: []int{0, 8},
+			wantPmds: []int{0, 8}, wantMain: 1, wantShared: []int{0, 8},
+		},
+		{
+			name: "pinned remote", cpus: all, portNodes: []int{0}, policy: corePolicyLocalStrict, pinned: []int{12},
+			wantErr: "mapped lcore 12 is on numa node 1",
+		},
+		{
+			name: "pinned remote preferred", cpus: all, portNodes: []int{0}, policy: corePolicyLocalPreferred, pinned: []int{12},
+			wantPmds: []int{12}, wantMain: 0,
+		},
+		{
+			name: "pinned not allowed", cpus: cpuset.NewCPUSet(0, 1), portNodes: []int{0}, policy: corePolicyAny, pinned: []int{2},
+			wantErr: "mapped lcore 2 is not in the allowed cpus",
+		},
+		{
+			name: "unknown policy", cpus: all, nPmd: 1, policy: "numa",
+			wantErr: "unknown core policy numa",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := newCorePlan(testTopology(), tt.cpus, tt.portNodes, tt.nPmd, tt.policy, tt.pinned)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(plan.pmdLcores, tt.wantPmds) || plan.mainLcore != tt.wantMain {
+				t.Errorf("pmd lcores %v and main lcore %d, want %v and %d", plan.pmdLcores, plan.mainLcore, tt.wantPmds, tt.wantMain)
+			}
+			if plan.pinned != (tt.pinned != nil) {
+				t.Errorf("pinned %v", plan.pinned)
+			}
+			if shared := plan.sharedPmds(); !reflect.DeepEqual(shared, tt.wantShared) {
+				t.Errorf("shared pmd lcores %v, want %v", shared, tt.wantShared)
+			}
+		})
+	}
+}
+
+func TestReadCpuTopology(t *testing.T) {
+	f, err := newFakeSysfs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.remove()
+	want := testTopology()
+	for cpu := 0; cpu < 16; cpu++ {
+		if err := f.addCpu(cpu, want.node[cpu], want.siblings[cpu]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// cpu 16 is missing from sysfs
+	topo := readCpuTopology(f.root, cpuset.MustParse("0-16"))
+	for cpu := 0; cpu < 16; cpu++ {
+		if topo.nodeOf(cpu) != want.node[cpu] || !topo.siblingsOf(cpu).Equals(want.siblings[cpu]) {
+			t.Errorf("cpu %d on node %d with siblings %s, want %d and %s",
+				cpu, topo.nodeOf(cpu), topo.siblingsOf(cpu), want.node[cpu], want.siblings[cpu])
+		}
+	}
+	if topo.nodeOf(16) != -1 || !topo.siblingsOf(16).Equals(cpuset.NewCPUSet(16)) {
+		t.Errorf("unknown cpu 16 on node %d with siblings %s", topo.nodeOf(16), topo.siblingsOf(16))
+	}
+}
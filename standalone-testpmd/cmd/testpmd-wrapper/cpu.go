@@ -2,11 +2,18 @@ package main
 
 import (
 	"io/ioutil"
+	"path/filepath"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"k8s.io/kubernetes/pkg/kubelet/cm/cpuset"
 )
 
+const cpuDir = "devices/system/cpu"
+
+var nodeDirRE = regexp.MustCompile(`^node(\d+)$`)
+
 func getProcCpuset() cpuset.CPUSet {
 	content, err := ioutil.ReadFile("/proc/self/status")
 	if err != nil {
@@ -16,3 +23,61 @@ func getProcCpuset() cpuset.CPUSet {
 	cpus := r.FindStringSubmatch(string(content))[1]
 	return cpuset.MustParse(cpus)
 }
+
+// cpuTopology tells the numa node and the hyperthread siblings of each cpu
+type cpuTopology struct {
+	// -1 if unknown
+	node map[int]int
+	// the cpus sharing the physical core, the cpu itself included
+	siblings map[int]cpuset.CPUSet
+}
+
+func (c *cpuTopology) nodeOf(cpu int) int {
+	if node, ok := c.node[cpu]; ok {
+		return node
+	}
+	return -1
+}
+
+func (c *cpuTopology) siblingsOf(cpu int) cpuset.CPUSet {
+	if s, ok := c.siblings[cpu]; ok {
+		return s
+	}
+	return cpuset.NewCPUSet(cpu)
+}
+
+// readCpuTopology reads the topology of the cpus under a sysfs root. What sysfs doesn't tell is
+// left out: the cpu is on an unknown node and is its own physical core.
+func readCpuTopology(root string, cpus cpuset.CPUSet) *cpuTopology {
+	topo := &cpuTopology{node: make(map[int]int), siblings: make(map[int]cpuset.CPUSet)}
+	nodes, _ := ioutil.ReadDir(filepath.Join(root, numaNodeDir))
+	for _, n := range nodes {
+		m := nodeDirRE.FindStringSubmatch(n.Name())
+		if m == nil {
+			continue
+		}
+		node, _ := strconv.Atoi(m[1])
+		out, err := ioutil.ReadFile(filepath.Join(root, numaNodeDir, n.Name(), "cpulist"))
+		if err != nil {
+			continue
+		}
+		list, err := cpuset.Parse(strings.TrimSpace(string(out)))
+		if err != nil {
+			continue
+		}
+		for _, cpu := range list.ToSlice() {
+			topo.node[cpu] = node
+		}
+	}
+	for _, cpu := range cpus.ToSlice() {
+		path := filepath.Join(root, cpuDir, "cpu"+strconv.Itoa(cpu), "topology", "thread_siblings_list")
+		out, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if s, err := cpuset.Parse(strings.TrimSpace(string(out))); err == nil {
+			topo.siblings[cpu] = s
+		}
+	}
+	return topo
+}
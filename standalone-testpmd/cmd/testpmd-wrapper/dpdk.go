@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/lithammer/shortuuid"
+)
+
+const probeTimeout = 10 * time.Second
+
+var (
+	// EAL -v prints "EAL: RTE Version: 'DPDK 20.11.0'"
+	ealVersionRE = regexp.MustCompile(`RTE Version: 'DPDK (\d+\.\d+(?:\.\d+)?)`)
+	// rte_version() string built into the binary
+	binaryVersionRE = regexp.MustCompile(`DPDK (\d+\.\d+\.\d+)`)
+	versionRE       = regexp.MustCompile(`^(\d+)\.(\d+)(?:\.(\d+))?`)
+)
+
+// dpdkRelease holds the EAL and testpmd options that changed between DPDK LTS releases
+type dpdkRelease struct {
+	major int
+	minor int
+	// allow a pci device, -w before 20.11
+	allowFlag string
+	// main lcore, --master-lcore before 20.11
+	mainLcoreFlag string
+	// name of the installed binary, meson builds install dpdk-testpmd
+	binary string
+}
+
+func (r *dpdkRelease) String() string {
+	return fmt.Sprintf("%d.%02d", r.major, r.minor)
+}
+
+// dpdkReleases is the compatibility table, oldest first
+var dpdkReleases = []*dpdkRelease{
+	{major: 18, minor: 11, allowFlag: "-w", mainLcoreFlag: "--master-lcore", binary: "testpmd"},
+	{major: 19, minor: 11, allowFlag: "-w", mainLcoreFlag: "--master-lcore", binary: "testpmd"},
+	{major: 20, minor: 11, allowFlag: "-a", mainLcoreFlag: "--main-lcore", binary: "dpdk-testpmd"},
+	{major: 21, minor: 11, allowFlag: "-a", mainLcoreFlag: "--main-lcore", binary: "dpdk-testpmd"},
+	{major: 22, minor: 11, allowFlag: "-a", mainLcoreFlag: "--main-lcore", binary: "dpdk-testpmd"},
+	{major: 23, minor: 11, allowFlag: "-a", mainLcoreFlag: "--main-lcore", binary: "dpdk-testpmd"},
+}
+
+// assumed when the version can't be detected, the options this wrapper always used
+var defaultDpdkRelease = dpdkReleases[1]
+
+// dpdkReleaseFor returns the newest release of the table not newer than version
+func dpdkReleaseFor(version string) (*dpdkRelease, error) {
+	m := versionRE.FindStringSubmatch(version)
+	if m == nil {
+		return nil, fmt.Errorf("invalid DPDK version %q", version)
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	for i := len(dpdkReleases) - 1; i >= 0; i-- {
+		r := dpdkReleases[i]
+		if major > r.major || (major == r.major && minor >= r.minor) {
+			return r, nil
+		}
+	}
+	return nil, fmt.Errorf("DPDK %s is older than %s, the oldest supported release", version, dpdkReleases[0])
+}
+
+// resolveTestpmd looks the binary up in PATH, trying the name of the other releases
+// if it isn't found under the given one
+func resolveTestpmd(path string) (string, error) {
+	if p, err := exec.LookPath(path); err == nil {
+		return p, nil
+	}
+	for _, r := range dpdkReleases {
+		if filepath.Base(path) == r.binary {
+			continue
+		}
+		alt := r.binary
+		if filepath.Base(path) != path {
+			alt = filepath.Join(filepath.Dir(path), r.binary)
+		}
+		if p, err := exec.LookPath(alt); err == nil {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("testpmd not found at %s", path)
+}
+
+// probeDpdkVersion runs testpmd with EAL -v and no devices to get the version it prints
+// before the EAL init, then falls back to the version string built into the binary
+func probeDpdkVersion(path string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, path, "-v", "--no-huge", "--no-pci", "-m", "64",
+		"--file-prefix", "probe-"+shortuuid.New(), "--", "--help")
+	out, _ := cmd.CombinedOutput()
+	if m := ealVersionRE.FindSubmatch(out); m != nil {
+		return string(m[1]), nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if m := binaryVersionRE.FindSubmatch(data); m != nil {
+		return string(m[1]), nil
+	}
+	return "", fmt.Errorf("no DPDK version found in %s", path)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDpdkReleaseFor(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"18.11.2", "18.11"},
+		{"19.08.0", "18.11"},
+		{"19.11", "19.11"},
+		{"20.11.3", "20.11"},
+		{"21.02.0-rc1", "20.11"},
+		{"24.03.0", "23.11"},
+		{"17.11.10", ""},
+		{"unknown", ""},
+	}
+	for _, tt := range tests {
+		r, err := dpdkReleaseFor(tt.version)
+		switch {
+		case tt.want == "" && err == nil:
+			t.Errorf("%s uses the options of %s, want an error", tt.version, r)
+		case tt.want != "" && err != nil:
+			t.Errorf("%s: %v", tt.version, err)
+		case tt.want != "" && r.String() != tt.want:
+			t.Errorf("%s uses the options of %s, want %s", tt.version, r, tt.want)
+		}
+	}
+}
+
+func TestInitOptionsPerRelease(t *testing.T) {
+	pci := pciArray{"0000:86:00.0", "0000:86:00.1"}
+	vdevs := vdevArray{"net_null0"}
+	for _, r := range dpdkReleases {
+		t.Run(r.String(), func(t *testing.T) {
+			b, err := newSimBackend(pci, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer b.close()
+			tp := &testpmd{b: b}
+			if err := tp.detectDpdk(r.binary, r.String()); err != nil {
+				t.Fatal(err)
+			}
+			if tp.dpdk != r {
+				t.Fatalf("DPDK %s uses the options of %s", r, tp.dpdk)
+			}
+			if err := tp.init(pci, vdevs, nil, 1, 512, defaultMbufSize, r.binary); err != nil {
+				t.Fatal(err)
+			}
+			defer tp.stop()
+			cmd := tp.getRunInfo().cmdline
+			old := r.major < 20
+			for _, o := range []struct {
+				option string
+				want   bool
+			}{
+				{"-w 0000:86:00.0 ", old},
+				{"-w 0000:86:00.1 ", old},
+				{"-a 0000:86:00.0 ", !old},
+				{"-a 0000:86:00.1 ", !old},
+				{fmt.Sprintf("--master-lcore %d ", tp.cores.mainLcore), old},
+				{fmt.Sprintf("--main-lcore %d ", tp.cores.mainLcore), !old},
+			} {
+				if strings.Contains(cmd, " "+o.option) != o.want {
+					t.Errorf("%q in command line is %v, want %v: %s", o.option, !o.want, o.want, cmd)
+				}
+			}
+			if !strings.HasPrefix(cmd, r.binary+" ") {
+				t.Errorf("command line doesn't start with %s: %s", r.binary, cmd)
+			}
+			// the simulated testpmd found the ports on the allow list and its main lcore
+			output, err := tp.listPorts(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			ports, err := parsePortList(output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ports) != 3 {
+				t.Fatalf("testpmd has %d ports, want 3: %s", len(ports), output)
+			}
+			for i, name := range []string{"0000:86:00.0", "0000:86:00.1", "net_null0"} {
+				if ports[i].PciAddress != name {
+					t.Errorf("port %d is %s, want %s", i, ports[i].PciAddress, name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// dpdk drivers a port can be bound to, besides the kernel driver of bifurcated NICs like mlx5
+var dpdkDrivers = []string{"vfio-pci", "igb_uio", "uio_pci_generic"}
+
+const fallbackDpdkDriver = "vfio-pci"
+
+type kdDrivers struct {
+	kernel string
+	dpdk   string
+}
+
+// device id, or "default" for the devices of the vendor not listed
+type deviceDriverMap map[string]*kdDrivers
+
+var (
+	i40eDrivers    = &kdDrivers{kernel: "i40e", dpdk: "vfio-pci"}
+	iavfDrivers    = &kdDrivers{kernel: "iavf", dpdk: "vfio-pci"}
+	iceDrivers     = &kdDrivers{kernel: "ice", dpdk: "vfio-pci"}
+	ixgbeDrivers   = &kdDrivers{kernel: "ixgbe", dpdk: "vfio-pci"}
+	ixgbevfDrivers = &kdDrivers{kernel: "ixgbevf", dpdk: "vfio-pci"}
+	bnxtDrivers    = &kdDrivers{kernel: "bnxt_en", dpdk: "vfio-pci"}
+	virtioDrivers  = &kdDrivers{kernel: "virtio-pci", dpdk: "vfio-pci"}
+	mlx4Drivers    = &kdDrivers{kernel: "mlx4_core", dpdk: "mlx4_core"}
+	mlx5Drivers    = &kdDrivers{kernel: "mlx5_core", dpdk: "mlx5_core"}
+)
+
+// vendorDriverMap is the built-in table, -driver-table extends or overrides it
+var vendorDriverMap = map[string]deviceDriverMap{
+	// Intel
+	"0x8086": {
+		// X710, XL710, XXV710, X722
+		"0x1572": i40eDrivers,
+		"0x1583": i40eDrivers,
+		"0x1584": i40eDrivers,
+		"0x1585": i40eDrivers,
+		"0x158a": i40eDrivers,
+		"0x158b": i40eDrivers,
+		"0x37d0": i40eDrivers,
+		"0x37d2": i40eDrivers,
+		// 700 and 800 series VFs, adaptive VF
+		"0x154c": iavfDrivers,
+		"0x37cd": iavfDrivers,
+		"0x1889": iavfDrivers,
+		// E810
+		"0x1591": iceDrivers,
+		"0x1592": iceDrivers,
+		"0x1593": iceDrivers,
+		"0x159b": iceDrivers,
+		// 82599, X540, X550
+		"0x10fb": ixgbeDrivers,
+		"0x1528": ixgbeDrivers,
+		"0x1563": ixgbeDrivers,
+		"0x15ab": ixgbeDrivers,
+		"0x154d": ixgbeDrivers,
+		"0x1557": ixgbeDrivers,
+		"0x10ed": ixgbevfDrivers,
+		"0x1515": ixgbevfDrivers,
+		"0x1565": ixgbevfDrivers,
+		"0x15a8": ixgbevfDrivers,
+	},
+	// Broadcom NetXtreme-C/E
+	"0x14e4": {
+		"0x16d7": bnxtDrivers,
+		"0x16d8": bnxtDrivers,
+		"0x1750": bnxtDrivers,
+		"0x16c1": bnxtDrivers,
+		"0x1806": bnxtDrivers,
+		"0x1807": bnxtDrivers,
+	},
+	// Red Hat virtio, legacy and modern
+	"0x1af4": {
+		"0x1000": virtioDrivers,
+		"0x1041": virtioDrivers,
+	},
+	// Mellanox, ConnectX-3 on mlx4, the later ones on mlx5
+	"0x15b3": {
+		"0x1003":  mlx4Drivers,
+		"0x1007":  mlx4Drivers,
+		"default": mlx5Drivers,
+	},
+}
+
+// driverTableEntry is an entry of the -driver-table file
+type driverTableEntry struct {
+	Vendor string `json:"vendor"`
+	Device string `json:"device"`
+	Kernel string `json:"kernel"`
+	Dpdk   string `json:"dpdk"`
+}
+
+// tableID lowercases a vendor or device id and adds the 0x prefix sysfs uses
+func tableID(id string) string {
+	id = strings.ToLower(strings.TrimSpace(id))
+	if id == "" || id == "default" || strings.HasPrefix(id, "0x") {
+		return id
+	}
+	return "0x" + id
+}
+
+// loadDriverTable adds the entries of a yaml or json file to the table, replacing the built-in
+// entries for the same vendor and device
+func loadDriverTable(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var entries []driverTableEntry
+	if err := yaml.UnmarshalStrict(data, &entries); err != nil {
+		return fmt.Errorf("invalid driver table %s: %v", path, err)
+	}
+	for i, e := range entries {
+		vendor, device := tableID(e.Vendor), tableID(e.Device)
+		if vendor == "" || e.Kernel == "" || e.Dpdk == "" {
+			return fmt.Errorf("driver table %s: entry %d needs vendor, kernel and dpdk", path, i)
+		}
+		if device == "" {
+			device = "default"
+		}
+		if e.Dpdk != e.Kernel && !containsString(dpdkDrivers, e.Dpdk) {
+			return fmt.Errorf("driver table %s: entry %d: %s is not a dpdk driver, expect one of %s or the kernel driver",
+				path, i, e.Dpdk, strings.Join(dpdkDrivers, ", "))
+		}
+		if vendorDriverMap[vendor] == nil {
+			vendorDriverMap[vendor] = make(deviceDriverMap)
+		}
+		vendorDriverMap[vendor][device] = &kdDrivers{kernel: e.Kernel, dpdk: e.Dpdk}
+		log.Printf("driver table: %s:%s uses %s, dpdk %s", vendor, device, e.Kernel, e.Dpdk)
+	}
+	return nil
+}
+
+// getDriverFromDeviceVendor looks the device up in the table, then the vendor default
+func getDriverFromDeviceVendor(vendor string, device string) (*kdDrivers, bool) {
+	deviceMap, ok := vendorDriverMap[vendor]
+	if !ok {
+		return nil, false
+	}
+	if driver, ok := deviceMap[device]; ok {
+		return driver, true
+	}
+	driver, ok := deviceMap["default"]
+	return driver, ok
+}
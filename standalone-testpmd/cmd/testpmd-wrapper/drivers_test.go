@@ -0,0 +1,205 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+// saveDriverTable returns a func that puts back the table as it is now, loadDriverTable
+// changes it in place
+func saveDriverTable() func() {
+	saved := make(map[string]deviceDriverMap)
+	for vendor, devices := range vendorDriverMap {
+		saved[vendor] = make(deviceDriverMap)
+		for device, drivers := range devices {
+			saved[vendor][device] = drivers
+		}
+	}
+	return func() { vendorDriverMap = saved }
+}
+
+func TestGetDriverFromDeviceVendor(t *testing.T) {
+	tests := []struct {
+		name   string
+		vendor string
+		device string
+		// nil if the device is not in the table
+		want *kdDrivers
+	}{
+		{"X710", "0x8086", "0x1572", i40eDrivers},
+		{"XL710 VF", "0x8086", "0x154c", iavfDrivers},
+		{"E810", "0x8086", "0x159b", iceDrivers},
+		{"82599 VF", "0x8086", "0x10ed", ixgbevfDrivers},
+		{"unknown intel device", "0x8086", "0xffff", nil},
+		{"ConnectX-3", "0x15b3", "0x1007", mlx4Drivers},
+		{"ConnectX-5 by vendor default", "0x15b3", "0x1017", mlx5Drivers},
+		{"virtio", "0x1af4", "0x1041", virtioDrivers},
+		{"unknown vendor", "0x1234", "0x5678", nil},
+		{"id without prefix", "8086", "1572", nil},
+	}
+	for _, tt := range tests {
+		drivers, ok := getDriverFromDeviceVendor(tt.vendor, tt.device)
+		if ok != (tt.want != nil) || drivers != tt.want {
+			t.Errorf("%s: drivers %+v (%v), want %+v", tt.name, drivers, ok, tt.want)
+		}
+	}
+}
+
+func TestTableID(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"0x8086", "0x8086"},
+		{"8086", "0x8086"},
+		{" 15B3 ", "0x15b3"},
+		{"0x158A", "0x158a"},
+		{"default", "default"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := tableID(tt.in); got != tt.want {
+			t.Errorf("tableID(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLoadDriverTable(t *testing.T) {
+	tests := []struct {
+		name  string
+		table string
+		// the drivers of each "vendor:device" after loading, nil if not in the table
+		want map[string]*kdDrivers
+		// part of the error, none if empty
+		wantErr string
+	}{
+		{
+			name: "override and add",
+			table: `
+- vendor: "8086"
+  device: "1572"
+  kernel: i40e
+  dpdk: igb_uio
+- vendor: "0x1234"
+  device: "0x5678"
+  kernel: foo_net
+  dpdk: vfio-pci
+- vendor: "1d0f"
+  kernel: ena
+  dpdk: ena
+`,
+			want: map[string]*kdDrivers{
+				"0x8086:0x1572": {kernel: "i40e", dpdk: "igb_uio"},
+				"0x8086:0x1583": i40eDrivers,
+				"0x1234:0x5678": {kernel: "foo_net", dpdk: "vfio-pci"},
+				"0x1234:0x9999": nil,
+				"0x1d0f:0xec20": {kernel: "ena", dpdk: "ena"},
+			},
+		},
+		{
+			name:  "json",
+			table: `[{"vendor": "0x15b3", "device": "0x1017", "kernel": "mlx5_core", "dpdk": "vfio-pci"}]`,
+			want: map[string]*kdDrivers{
+				"0x15b3:0x1017": {kernel: "mlx5_core", dpdk: "vfio-pci"},
+				"0x15b3:0x1019": mlx5Drivers,
+			},
+		},
+		{
+			name:    "malformed",
+			table:   "- vendor: 8086\n  device: [1572\n",
+			wantErr: "invalid driver table",
+		},
+		{
+			name:    "unknown field",
+			table:   "- vendor: \"8086\"\n  kernel: i40e\n  dpdk: vfio-pci\n  driver: i40e\n",
+			wantErr: "invalid driver table",
+		},
+		{
+			name:    "missing kernel driver",
+			table:   "- vendor: \"8086\"\n  device: \"1572\"\n  dpdk: vfio-pci\n",
+			wantErr: "entry 0 needs vendor, kernel and dpdk",
+		},
+		{
+			name:    "not a dpdk driver",
+			table:   "- vendor: \"8086\"\n  kernel: i40e\n  dpdk: ixgbe\n",
+			wantErr: "entry 0: ixgbe is not a dpdk driver",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer saveDriverTable()()
+			f, err := ioutil.TempFile("", "drivers")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove(f.Name())
+			f.WriteString(tt.table)
+			f.Close()
+			err = loadDriverTable(f.Name())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for id, want := range tt.want {
+				ids := strings.Split(id, ":")
+				drivers, ok := getDriverFromDeviceVendor(ids[0], ids[1])
+				if ok != (want != nil) || (ok && *drivers != *want) {
+					t.Errorf("%s: drivers %+v (%v), want %+v", id, drivers, ok, want)
+				}
+			}
+		})
+	}
+
+	if err := loadDriverTable("/nonexistent/drivers.yaml"); err == nil {
+		t.Error("no error for a missing driver table")
+	}
+}
+
+// A device missing from the driver table goes to the fallback dpdk driver, the driver it is
+// on is taken as its kernel driver if it is a kernel port
+func TestGetPciInfoFallback(t *testing.T) {
+	tests := []struct {
+		name string
+		// the driver the unknown device is on, unbound if empty
+		driver string
+		want   pciInfo
+	}{
+		{
+			name:   "kernel port",
+			driver: unknownNetDr,
+			want:   pciInfo{driverPre: unknownNetDr, kmod: unknownNetDr, dpdk: fallbackDpdkDriver, wasKernelPort: true},
+		},
+		{
+			name: "unbound",
+			want: pciInfo{dpdk: fallbackDpdkDriver},
+		},
+		{
+			name:   "on a dpdk driver",
+			driver: vfioDriver,
+			want:   pciInfo{driverPre: vfioDriver, dpdk: fallbackDpdkDriver},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newPortsSysfs(t)
+			defer f.remove()
+			const p = "0000:5e:00.1"
+			if err := f.addDevice(p, "0x1234", "0x5678", 0, tt.driver, "eth10"); err != nil {
+				t.Fatal(err)
+			}
+			info, err := f.bus().getPciInfo(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := pciInfo{driverPre: info.driverPre, kmod: info.kmod, dpdk: info.dpdk,
+				wasKernelPort: info.wasKernelPort, dpdkUseKmod: info.dpdkUseKmod}
+			if got != tt.want {
+				t.Errorf("info %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	expect "github.com/google/goexpect"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const errorDomain = "testpmd-wrapper"
+
+var (
+	errNotRunning       = errors.New("testpmd is not running")
+	errRejected         = errors.New("testpmd rejected the command")
+	errUnexpectedOutput = errors.New("unexpected testpmd output")
+	errNotForwarding    = errors.New("testpmd didn't start forwarding")
+	errPortsUnknown     = errors.New("the port settings are unknown")
+)
+
+// argError is a request the wrapper can't serve as asked
+type argError struct {
+	msg string
+}
+
+func (e *argError) Error() string {
+	return e.msg
+}
+
+func invalidArgument(format string, a ...interface{}) error {
+	return &argError{msg: fmt.Sprintf(format, a...)}
+}
+
+// cmdError is a testpmd command that failed, or whose output wasn't what was expected
+type cmdError struct {
+	cmd    string
+	output string
+	err    error
+}
+
+func (e *cmdError) Error() string {
+	return fmt.Sprintf("%q: %v", e.cmd, e.err)
+}
+
+func (e *cmdError) Unwrap() error {
+	return e.err
+}
+
+// grpcError turns an error into a grpc status. A failed testpmd command is attached as an
+// ErrorInfo detail with the command and its output in the metadata.
+func grpcError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	code, reason := codes.Internal, "INTERNAL"
+	var argErr *argError
+	var timeout expect.TimeoutError
+	switch {
+	case errors.As(err, &argErr):
+		code, reason = codes.InvalidArgument, "INVALID_ARGUMENT"
+	case errors.Is(err, errNotForwarding):
+		code, reason = codes.FailedPrecondition, "FORWARDING_NOT_STARTED"
+	case errors.Is(err, errPortsUnknown):
+		code, reason = codes.Internal, "PORT_SETTINGS_UNKNOWN"
+	case errors.Is(err, errRejected):
+		code, reason = codes.InvalidArgument, "COMMAND_REJECTED"
+	case errors.As(err, &timeout), errors.Is(err, context.DeadlineExceeded):
+		code, reason = codes.DeadlineExceeded, "COMMAND_TIMEOUT"
+	case errors.Is(err, context.Canceled):
+		code, reason = codes.Canceled, "CANCELED"
+	case errors.Is(err, errNotRunning), errors.Is(err, errExecutorStopped), errors.Is(err, io.EOF):
+		code, reason = codes.Unavailable, "TESTPMD_UNAVAILABLE"
+	case errors.Is(err, errBusy):
+		code, reason = codes.Unavailable, "TESTPMD_BUSY"
+	case errors.Is(err, errUnexpectedOutput):
+		reason = "UNEXPECTED_OUTPUT"
+	}
+	st := status.New(code, err.Error())
+	var ce *cmdError
+	if errors.As(err, &ce) {
+		info := &errdetails.ErrorInfo{
+			Reason: reason,
+			Domain: errorDomain,
+			Metadata: map[string]string{
+				"command": ce.cmd,
+				"output":  strings.TrimSpace(promptRE.ReplaceAllString(ce.output, "")),
+			},
+		}
+		if d, derr := st.WithDetails(info); derr == nil {
+			st = d
+		}
+	}
+	return st.Err()
+}
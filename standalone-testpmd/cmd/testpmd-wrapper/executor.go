@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+var (
+	errExecutorStopped = errors.New("testpmd session is closed")
+	errBusy            = errors.New("testpmd is still busy with timed out commands")
+)
+
+type cmdRequest struct {
+	ctx     context.Context
+	cmd     string
+	timeout time.Duration
+	// buffered so the executor never blocks on a caller that went away
+	reply chan cmdReply
+}
+
+type cmdReply struct {
+	output string
+	err    error
+}
+
+// cmdExecutor owns the interactive testpmd session. Commands from all callers are
+// queued and run one at a time on a single goroutine, so every caller gets the
+// output of its own command.
+type cmdExecutor struct {
+	e        session
+	requests chan *cmdRequest
+	quit     chan struct{}
+	done     chan struct{}
+	// prompts of timed out commands still in flight, their output must not be taken for the
+	// output of the next command
+	pending int
+}
+
+func newCmdExecutor(e session) *cmdExecutor {
+	x := &cmdExecutor{
+		e:        e,
+		requests: make(chan *cmdRequest),
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go x.loop()
+	return x
+}
+
+func (x *cmdExecutor) loop() {
+	defer close(x.done)
+	for {
+		select {
+		case <-x.quit:
+			return
+		case req := <-x.requests:
+			if err := req.ctx.Err(); err != nil {
+				req.reply <- cmdReply{err: err}
+				continue
+			}
+			output, err := x.exec(req.cmd, req.timeout)
+			req.reply <- cmdReply{output: output, err: err}
+		}
+	}
+}
+
+func (x *cmdExecutor) exec(cmd string, timeout time.Duration) (string, error) {
+	if x.pending > 0 {
+		if err := x.resync(timeout); err != nil {
+			return "", err
+		}
+	}
+	if err := x.e.Send(cmd + "\n"); err != nil {
+		return "", err
+	}
+	output, _, err := x.e.Expect(promptRE, timeout)
+	log.Println(output)
+	if err != nil {
+		log.Printf("%q failed: %v", cmd, err)
+		x.pending++
+	}
+	return output, err
+}
+
+// resync drops the output of the timed out commands, waiting up to timeout for each of their
+// prompts. A command that is still busy fails the next one without sending it, as testpmd would
+// only run it after, and resync is tried again before the one after.
+func (x *cmdExecutor) resync(timeout time.Duration) error {
+	for x.pending > 0 {
+		output, _, err := x.e.Expect(promptRE, timeout)
+		if err != nil {
+			return fmt.Errorf("%w, %d left: %v", errBusy, x.pending, err)
+		}
+		log.Printf("dropping the late output of a timed out command: %s", output)
+		x.pending--
+	}
+	return nil
+}
+
+// run queues cmd and waits for its output. If ctx is done before the command
+// completes, run returns immediately and the executor discards the output.
+func (x *cmdExecutor) run(ctx context.Context, cmd string, timeout time.Duration) (string, error) {
+	req := &cmdRequest{ctx: ctx, cmd: cmd, timeout: timeout, reply: make(chan cmdReply, 1)}
+	select {
+	case x.requests <- req:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-x.done:
+		return "", errExecutorStopped
+	}
+	select {
+	case r := <-req.reply:
+		return r.output, r.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// close waits for the running command and stops the executor, then closes the session
+func (x *cmdExecutor) close() error {
+	close(x.quit)
+	<-x.done
+	return x.e.Close()
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testPorts = 8
+
+func newTestExecutor(t *testing.T, e session) *cmdExecutor {
+	t.Helper()
+	if _, _, err := e.Expect(promptRE, time.Second); err != nil {
+		t.Fatalf("no first prompt: %v", err)
+	}
+	return newCmdExecutor(e)
+}
+
+func simCmdline(ports int) string {
+	cmd := "testpmd -l 0-1"
+	for i := 0; i < ports; i++ {
+		cmd += fmt.Sprintf(" --vdev net_null%d", i)
+	}
+	return cmd + " -- -i"
+}
+
+// slowSession holds back the output of the commands starting with "slow" for delay
+type slowSession struct {
+	*simSession
+	delay time.Duration
+}
+
+func (s *slowSession) Send(in string) error {
+	if !strings.HasPrefix(in, "slow") {
+		return s.simSession.Send(in)
+	}
+	go func() {
+		time.Sleep(s.delay)
+		s.simSession.Send(in)
+	}()
+	return nil
+}
+
+// checkOwnOutput fails unless output is the echo and the device info of port
+func checkOwnOutput(t *testing.T, output string, port int) {
+	t.Helper()
+	cmd := fmt.Sprintf("show device info net_null%d", port)
+	// the space after the previous prompt comes first
+	if !strings.HasPrefix(strings.TrimLeft(output, " "), cmd+"\n") {
+		t.Errorf("output of %q is for another command: %q", cmd, output)
+		return
+	}
+	if strings.Count(output, "testpmd>") != 1 {
+		t.Errorf("output of %q has several prompts: %q", cmd, output)
+	}
+	if !strings.Contains(output, fmt.Sprintf("Device name: net_null%d\n", port)) {
+		t.Errorf("output of %q has no device name: %q", cmd, output)
+	}
+}
+
+func TestExecutorParallel(t *testing.T) {
+	x := newTestExecutor(t, newSimSession(simCmdline(testPorts)))
+	defer x.close()
+	var wg sync.WaitGroup
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func(port int) {
+			defer wg.Done()
+			output, err := x.run(context.Background(), fmt.Sprintf("show device info net_null%d", port), time.Second)
+			if err != nil {
+				t.Errorf("port %d: %v", port, err)
+				return
+			}
+			checkOwnOutput(t, output, port)
+		}(i % testPorts)
+	}
+	wg.Wait()
+}
+
+func TestExecutorCanceled(t *testing.T) {
+	x := newTestExecutor(t, newSimSession(simCmdline(1)))
+	defer x.close()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := x.run(ctx, "show device info net_null0", time.Second); !errors.Is(err, context.Canceled) {
+		t.Fatalf("canceled run returned %v", err)
+	}
+	output, err := x.run(context.Background(), "show device info net_null0", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOwnOutput(t, output, 0)
+}
+
+func TestExecutorResync(t *testing.T) {
+	tests := []struct {
+		name  string
+		delay time.Duration
+		// the timeout of the command after the one that timed out
+		timeout time.Duration
+		// the command after runs only once the slow one is done
+		busy bool
+	}{
+		{name: "late output dropped", delay: 500 * time.Millisecond, timeout: 2 * time.Second},
+		{name: "still busy", delay: time.Second, timeout: 100 * time.Millisecond, busy: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := newTestExecutor(t, &slowSession{simSession: newSimSession(simCmdline(2)), delay: tt.delay})
+			defer x.close()
+			if _, err := x.run(context.Background(), "slow", 50*time.Millisecond); err == nil {
+				t.Fatal("slow command didn't time out")
+			}
+			output, err := x.run(context.Background(), "show device info net_null0", tt.timeout)
+			if tt.busy {
+				if !errors.Is(err, errBusy) {
+					t.Fatalf("command after a busy one returned %q, %v", output, err)
+				}
+				time.Sleep(tt.delay)
+			} else {
+				if err != nil {
+					t.Fatal(err)
+				}
+				checkOwnOutput(t, output, 0)
+			}
+			// the late output was consumed and isn't handed to a later caller either
+			output, err = x.run(context.Background(), "show device info net_null1", time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkOwnOutput(t, output, 1)
+			if x.pending != 0 {
+				t.Errorf("%d prompts still pending", x.pending)
+			}
+		})
+	}
+}
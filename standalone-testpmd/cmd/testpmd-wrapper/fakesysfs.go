@@ -0,0 +1,523 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpuset"
+)
+
+// fakeSysfs builds a pci sysfs tree in a temporary directory and emulates how the
+// kernel reacts on writes to the bind, unbind, new_id, remove_id, drivers_probe and sriov_numvfs
+// attributes, taking the driver_override of the devices into account. It also stands in for
+// netlink to set up VFs.
+// It also loads "modules" by adding their driver directory.
+type fakeSysfs struct {
+	root string
+
+	mu sync.Mutex
+	// "vendor device" ids added with new_id, per driver
+	newIDs map[string][]string
+	// drivers that register a net device when a device is bound to them
+	netDrivers map[string]bool
+	// net device name of each device, used when it is bound to a net driver
+	ifNames map[string]string
+	// driver a device is bound to when probed without override
+	nativeDrivers map[string]string
+	// SR-IOV PFs
+	pfs map[string]*fakePf
+	// settings of the net devices, by name, they are lost on unbind like the net device
+	netdevStates map[string]*netdevState
+	// the VF settings made through netlink, as ip link commands
+	vfLinkCmds []string
+	// the settings of the VFs, by PF net device, lost when the VFs are removed
+	vfSettings map[string]map[int]*vfSettings
+}
+
+// fakePf creates VFs of the given id and driver, each in its own iommu group
+type fakePf struct {
+	vfDevice   string
+	vfDriver   string
+	firstGroup int
+}
+
+func newFakeSysfs() (*fakeSysfs, error) {
+	root, err := ioutil.TempDir("", "fake-sysfs")
+	if err != nil {
+		return nil, err
+	}
+	f := &fakeSysfs{
+		root:          root,
+		newIDs:        make(map[string][]string),
+		netDrivers:    make(map[string]bool),
+		ifNames:       make(map[string]string),
+		nativeDrivers: make(map[string]string),
+		pfs:           make(map[string]*fakePf),
+		netdevStates:  make(map[string]*netdevState),
+		vfSettings:    make(map[string]map[int]*vfSettings),
+	}
+	for _, dir := range []string{pciDeviceDir, pciDriverDir} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (f *fakeSysfs) bus() *pciBus {
+	return &pciBus{root: f.root, writer: f, loader: f, netlink: f, netdevs: f}
+}
+
+// remove deletes the tree
+func (f *fakeSysfs) remove() error {
+	return os.RemoveAll(f.root)
+}
+
+// addDriver adds a driver, net tells if it creates a net device for its devices
+func (f *fakeSysfs) addDriver(driver string, net bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.netDrivers[driver] = net
+	return os.MkdirAll(f.bus().driverDir(driver), 0755)
+}
+
+// addDevice adds a pci device, bound to driver unless driver is empty
+func (f *fakeSysfs) addDevice(pci string, vendor string, device string, numa int, driver string, ifName string) error {
+	dir := f.bus().deviceDir(pci)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	attrs := map[string]string{
+		"vendor":    vendor,
+		"device":    device,
+		"numa_node": strconv.Itoa(numa),
+		// what the kernel shows when there is no override
+		"driver_override": "(null)",
+	}
+	for name, value := range attrs {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(value+"\n"), 0644); err != nil {
+			return err
+		}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.ifNames[pci] = ifName
+	f.nativeDrivers[pci] = driver
+	if driver == "" {
+		return nil
+	}
+	return f.doBind(pci, driver)
+}
+
+// addNumaNode adds a numa node with free huge pages of the given size
+func (f *fakeSysfs) addNumaNode(node int, pageSizeKB int, pages int) error {
+	dir := filepath.Join(f.root, numaNodeDir, fmt.Sprintf("node%d", node), "hugepages", fmt.Sprintf("hugepages-%dkB", pageSizeKB))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, name := range []string{"nr_hugepages", "free_hugepages"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(strconv.Itoa(pages)+"\n"), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addCpu adds a cpu to a numa node, siblings are the hardware threads of its physical core
+func (f *fakeSysfs) addCpu(cpu int, node int, siblings cpuset.CPUSet) error {
+	dir := filepath.Join(f.root, cpuDir, "cpu"+strconv.Itoa(cpu), "topology")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "thread_siblings_list"), []byte(siblings.String()+"\n"), 0644); err != nil {
+		return err
+	}
+	// the node lists its cpus
+	nodeDir := filepath.Join(f.root, numaNodeDir, fmt.Sprintf("node%d", node))
+	if err := os.MkdirAll(nodeDir, 0755); err != nil {
+		return err
+	}
+	cpus := cpuset.NewCPUSet(cpu)
+	if out, err := ioutil.ReadFile(filepath.Join(nodeDir, "cpulist")); err == nil {
+		if list, err := cpuset.Parse(strings.TrimSpace(string(out))); err == nil {
+			cpus = cpus.Union(list)
+		}
+	}
+	return ioutil.WriteFile(filepath.Join(nodeDir, "cpulist"), []byte(cpus.String()+"\n"), 0644)
+}
+
+// addIommuGroup puts devices in an iommu group
+func (f *fakeSysfs) addIommuGroup(group int, pci ...string) error {
+	groupDir := filepath.Join(f.root, iommuGroupsDir, strconv.Itoa(group))
+	if err := os.MkdirAll(filepath.Join(groupDir, "devices"), 0755); err != nil {
+		return err
+	}
+	for _, p := range pci {
+		dir := f.bus().deviceDir(p)
+		if err := os.Symlink(groupDir, filepath.Join(dir, "iommu_group")); err != nil {
+			return err
+		}
+		if err := os.Symlink(dir, filepath.Join(groupDir, "devices", p)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addPf makes a device an SR-IOV PF
+func (f *fakeSysfs) addPf(pci string, totalVfs int, vfDevice string, vfDriver string, firstGroup int) error {
+	dir := f.bus().deviceDir(pci)
+	for name, value := range map[string]int{"sriov_totalvfs": totalVfs, "sriov_numvfs": 0} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(strconv.Itoa(value)+"\n"), 0644); err != nil {
+			return err
+		}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pfs[pci] = &fakePf{vfDevice: vfDevice, vfDriver: vfDriver, firstGroup: firstGroup}
+	return nil
+}
+
+// vfAddress places the VFs of a PF function on the devices after it, 8 per device
+func vfAddress(pf string, vf int) string {
+	fn, _ := strconv.Atoi(pf[len(pf)-1:])
+	dev, _ := strconv.ParseInt(pf[len(pf)-4:len(pf)-2], 16, 32)
+	return fmt.Sprintf("%s%02x.%d", pf[:len(pf)-4], int(dev)+2+fn*8+vf/8, vf%8)
+}
+
+// setNumVfs emulates a write to sriov_numvfs, the count can only go from or to 0
+func (f *fakeSysfs) setNumVfs(pf string, value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("write sriov_numvfs: invalid argument")
+	}
+	dir := f.bus().deviceDir(pf)
+	f.mu.Lock()
+	p := f.pfs[pf]
+	f.mu.Unlock()
+	cur, _ := f.bus().numVfs(pf)
+	total, _ := ioutil.ReadFile(filepath.Join(dir, "sriov_totalvfs"))
+	if max, _ := strconv.Atoi(strings.TrimSpace(string(total))); p == nil || n > max {
+		return fmt.Errorf("write sriov_numvfs: invalid argument")
+	}
+	if cur != 0 && n != 0 && cur != n {
+		return fmt.Errorf("write sriov_numvfs: device or resource busy")
+	}
+	if n == 0 {
+		ifName, _ := f.bus().netDevice(pf)
+		f.mu.Lock()
+		delete(f.vfSettings, ifName)
+		f.mu.Unlock()
+		for vf := 0; vf < cur; vf++ {
+			vfPci := vfAddress(pf, vf)
+			f.mu.Lock()
+			if driver := f.bus().boundDriver(vfPci); driver != "" {
+				f.doUnbind(vfPci, driver)
+			}
+			f.mu.Unlock()
+			os.RemoveAll(filepath.Join(f.root, iommuGroupsDir, strconv.Itoa(p.firstGroup+vf)))
+			os.RemoveAll(f.bus().deviceDir(vfPci))
+			os.Remove(filepath.Join(dir, fmt.Sprintf("virtfn%d", vf)))
+		}
+	}
+	for vf := cur; vf < n; vf++ {
+		vfPci := vfAddress(pf, vf)
+		ifName, _ := f.bus().netDevice(pf)
+		if err := f.addDevice(vfPci, "0x8086", p.vfDevice, 0, p.vfDriver, fmt.Sprintf("%sv%d", ifName, vf)); err != nil {
+			return err
+		}
+		if err := f.addIommuGroup(p.firstGroup+vf, vfPci); err != nil {
+			return err
+		}
+		vfDir := f.bus().deviceDir(vfPci)
+		if err := os.Symlink(vfDir, filepath.Join(dir, fmt.Sprintf("virtfn%d", vf))); err != nil {
+			return err
+		}
+		if err := os.Symlink(dir, filepath.Join(vfDir, "physfn")); err != nil {
+			return err
+		}
+	}
+	return ioutil.WriteFile(filepath.Join(dir, "sriov_numvfs"), []byte(strconv.Itoa(n)+"\n"), 0644)
+}
+
+// pfNumVfs returns the pci address and VF count of the PF with the given net device
+func (f *fakeSysfs) pfNumVfs(pfName string) (string, int, error) {
+	for pf := range f.pfs {
+		if name, _ := f.bus().netDevice(pf); name == pfName {
+			n, err := f.bus().numVfs(pf)
+			return pf, n, err
+		}
+	}
+	return "", 0, fmt.Errorf("no SR-IOV net device %s", pfName)
+}
+
+// vf returns the settings of a VF of the PF net device
+func (f *fakeSysfs) vf(pfName string, vf int) (*vfSettings, error) {
+	_, n, err := f.pfNumVfs(pfName)
+	if err != nil {
+		return nil, err
+	}
+	if vf >= n {
+		return nil, fmt.Errorf("%s has no VF %d", pfName, vf)
+	}
+	if f.vfSettings[pfName] == nil {
+		f.vfSettings[pfName] = make(map[int]*vfSettings)
+	}
+	s, ok := f.vfSettings[pfName][vf]
+	if !ok {
+		// the kernel defaults
+		s = &vfSettings{Vf: vf, Mac: "00:00:00:00:00:00", Spoofchk: true}
+		f.vfSettings[pfName][vf] = s
+	}
+	return s, nil
+}
+
+// setVf applies a VF setting and records it as "ip link set <pf> vf <vf> <setting>", it fails if
+// the PF net device has no such VF
+func (f *fakeSysfs) setVf(pfName string, vf int, setting string, apply func(*vfSettings)) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.vf(pfName, vf)
+	if err != nil {
+		return err
+	}
+	apply(s)
+	f.vfLinkCmds = append(f.vfLinkCmds, fmt.Sprintf("ip link set %s vf %d %s", pfName, vf, setting))
+	return nil
+}
+
+func (f *fakeSysfs) getVfs(pfName string) ([]vfSettings, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, n, err := f.pfNumVfs(pfName)
+	if err != nil {
+		return nil, err
+	}
+	var vfs []vfSettings
+	for vf := 0; vf < n; vf++ {
+		s, err := f.vf(pfName, vf)
+		if err != nil {
+			return nil, err
+		}
+		vfs = append(vfs, *s)
+	}
+	return vfs, nil
+}
+
+func (f *fakeSysfs) setVfMac(pf string, vf int, mac net.HardwareAddr) error {
+	return f.setVf(pf, vf, "mac "+mac.String(), func(s *vfSettings) { s.Mac = mac.String() })
+}
+
+func (f *fakeSysfs) setVfVlan(pf string, vf int, vlan int) error {
+	if vlan < 0 || vlan > 4095 {
+		return fmt.Errorf("invalid vlan %d", vlan)
+	}
+	return f.setVf(pf, vf, fmt.Sprintf("vlan %d", vlan), func(s *vfSettings) { s.Vlan = vlan })
+}
+
+func (f *fakeSysfs) setVfTrust(pf string, vf int, on bool) error {
+	return f.setVf(pf, vf, "trust "+onOff(on), func(s *vfSettings) { s.Trust = on })
+}
+
+func (f *fakeSysfs) setVfSpoofchk(pf string, vf int, on bool) error {
+	return f.setVf(pf, vf, "spoofchk "+onOff(on), func(s *vfSettings) { s.Spoofchk = on })
+}
+
+// load emulates modprobe, the driver shows up under the pci drivers
+func (f *fakeSysfs) load(module string) error {
+	if _, err := os.Stat(f.bus().driverDir(module)); err == nil {
+		return nil
+	}
+	if module == vfioDriver {
+		// vfio-pci pulls in vfio and its parameters
+		param := filepath.Join(f.root, vfioNoIommuParam)
+		if err := os.MkdirAll(filepath.Dir(param), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(param, []byte("N\n"), 0644); err != nil {
+			return err
+		}
+	}
+	return f.addDriver(module, false)
+}
+
+func (f *fakeSysfs) writeFile(path string, data []byte) error {
+	rel, err := filepath.Rel(f.root, path)
+	if err != nil {
+		return err
+	}
+	if rel == pciDriversProbe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		return f.probe(strings.TrimSpace(string(data)))
+	}
+	parts := strings.Split(rel, string(filepath.Separator))
+	if len(parts) == 5 && filepath.Join(parts[:3]...) == pciDeviceDir && parts[4] == "sriov_numvfs" {
+		return f.setNumVfs(parts[3], strings.TrimSpace(string(data)))
+	}
+	// only the driver attributes and sriov_numvfs have side effects
+	if len(parts) != 5 || filepath.Join(parts[:3]...) != pciDriverDir {
+		return ioutil.WriteFile(path, data, 0644)
+	}
+	driver, attr, value := parts[3], parts[4], strings.TrimSpace(string(data))
+	if _, err := os.Stat(f.bus().driverDir(driver)); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch attr {
+	case "bind":
+		return f.doBind(value, driver)
+	case "unbind":
+		return f.doUnbind(value, driver)
+	case "new_id":
+		id, err := normalizeID(value)
+		if err != nil {
+			return err
+		}
+		f.newIDs[driver] = append(f.newIDs[driver], id)
+		// the driver probes every unbound device with this id
+		devices, _ := ioutil.ReadDir(filepath.Join(f.root, pciDeviceDir))
+		for _, d := range devices {
+			pci := d.Name()
+			if _, err := os.Lstat(filepath.Join(f.bus().deviceDir(pci), "driver")); err == nil {
+				continue
+			}
+			if f.deviceID(pci) == id {
+				if err := f.doBind(pci, driver); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	case "remove_id":
+		id, err := normalizeID(value)
+		if err != nil {
+			return err
+		}
+		ids := f.newIDs[driver]
+		for i, v := range ids {
+			if v == id {
+				f.newIDs[driver] = append(ids[:i], ids[i+1:]...)
+				return nil
+			}
+		}
+		return fmt.Errorf("write %s: no such device", path)
+	}
+	return fmt.Errorf("write %s: permission denied", path)
+}
+
+// probe emulates drivers_probe: an unbound device goes to its driver_override if it has one,
+// else to a driver that has its id from new_id, else to its native driver
+func (f *fakeSysfs) probe(pci string) error {
+	dir := f.bus().deviceDir(pci)
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("drivers_probe %s: no such device", pci)
+	}
+	if _, err := os.Lstat(filepath.Join(dir, "driver")); err == nil {
+		return nil
+	}
+	out, _ := ioutil.ReadFile(filepath.Join(dir, "driver_override"))
+	override := strings.TrimSpace(string(out))
+	if override != "" && override != "(null)" {
+		if _, err := os.Stat(f.bus().driverDir(override)); err != nil {
+			// no driver matches, the device stays unbound
+			return nil
+		}
+		return f.doBind(pci, override)
+	}
+	id := f.deviceID(pci)
+	for driver, ids := range f.newIDs {
+		if containsString(ids, id) {
+			return f.doBind(pci, driver)
+		}
+	}
+	if driver := f.nativeDrivers[pci]; driver != "" {
+		return f.doBind(pci, driver)
+	}
+	return nil
+}
+
+// normalizeID turns "0x8086 0x1572" into "8086 1572"
+func normalizeID(value string) (string, error) {
+	f := strings.Fields(value)
+	if len(f) < 2 {
+		return "", fmt.Errorf("invalid id %q", value)
+	}
+	return strings.TrimPrefix(f[0], "0x") + " " + strings.TrimPrefix(f[1], "0x"), nil
+}
+
+func (f *fakeSysfs) deviceID(pci string) string {
+	dir := f.bus().deviceDir(pci)
+	vendor, _ := ioutil.ReadFile(filepath.Join(dir, "vendor"))
+	device, _ := ioutil.ReadFile(filepath.Join(dir, "device"))
+	id, _ := normalizeID(string(vendor) + " " + string(device))
+	return id
+}
+
+func (f *fakeSysfs) doBind(pci string, driver string) error {
+	dir := f.bus().deviceDir(pci)
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("bind %s: no such device", pci)
+	}
+	if _, err := os.Lstat(filepath.Join(dir, "driver")); err == nil {
+		return fmt.Errorf("bind %s: device or resource busy", pci)
+	}
+	driverDir := f.bus().driverDir(driver)
+	if err := os.Symlink(driverDir, filepath.Join(dir, "driver")); err != nil {
+		return err
+	}
+	if err := os.Symlink(dir, filepath.Join(driverDir, pci)); err != nil {
+		return err
+	}
+	if f.netDrivers[driver] && f.ifNames[pci] != "" {
+		return os.MkdirAll(filepath.Join(dir, "net", f.ifNames[pci]), 0755)
+	}
+	return nil
+}
+
+func (f *fakeSysfs) doUnbind(pci string, driver string) error {
+	dir := f.bus().deviceDir(pci)
+	target, err := os.Readlink(filepath.Join(dir, "driver"))
+	if err != nil || filepath.Base(target) != driver {
+		return fmt.Errorf("unbind %s: no such device", pci)
+	}
+	for _, p := range []string{filepath.Join(dir, "driver"), filepath.Join(f.bus().driverDir(driver), pci)} {
+		if err := os.Remove(p); err != nil {
+			return err
+		}
+	}
+	delete(f.netdevStates, f.ifNames[pci])
+	return os.RemoveAll(filepath.Join(dir, "net"))
+}
+
+// snapshot returns the settings of a net device, the defaults of the driver if none were set
+func (f *fakeSysfs) snapshot(name string) (*netdevState, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if s, ok := f.netdevStates[name]; ok {
+		c := *s
+		return &c, nil
+	}
+	return &netdevState{Name: name, Mtu: 1500, Up: true, RxRing: 512, TxRing: 512, Combined: 4}, nil
+}
+
+// restore keeps the settings, the net device can't be renamed
+func (f *fakeSysfs) restore(name string, s *netdevState) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var failed []string
+	if name != s.Name {
+		failed = append(failed, fmt.Sprintf("name %s: operation not supported", s.Name))
+	}
+	c := *s
+	c.Name = name
+	f.netdevStates[name] = &c
+	return failed
+}
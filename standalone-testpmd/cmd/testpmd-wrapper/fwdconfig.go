@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	pb "github.com/redhat-nfvpe/container-perf-tools/standalone-testpmd/rpc"
+)
+
+var (
+	// "port:rxq@lcore"
+	queueMappingRE = regexp.MustCompile(`^(\d+):(\d+)@(\d+)$`)
+	// first line of "show config fwd"
+	fwdConfigHeaderRE = regexp.MustCompile(`^(\S+) packet forwarding.*- ports=(\d+) - cores=(\d+) - streams=(\d+)`)
+	fwdConfigLcoreRE  = regexp.MustCompile(`^Logical Core (\d+) \(socket (-?\d+)\) forwards packets on (\d+) streams`)
+	fwdConfigStreamRE = regexp.MustCompile(`RX P=(\d+)/Q=(\d+) \(socket (-?\d+)\) -> TX P=(\d+)/Q=(\d+) \(socket (-?\d+)\) peer=([0-9A-Fa-f:]+)`)
+)
+
+// queueMapping pins the rx queue of a port to a pmd lcore
+type queueMapping struct {
+	port  int
+	rxq   int
+	lcore int
+}
+
+type queueMapArray []queueMapping
+
+func parseQueueMapping(s string) (queueMapping, error) {
+	match := queueMappingRE.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return queueMapping{}, fmt.Errorf("invalid queue mapping %q, expect port:rxq@lcore", s)
+	}
+	port, _ := strconv.Atoi(match[1])
+	rxq, _ := strconv.Atoi(match[2])
+	lcore, _ := strconv.Atoi(match[3])
+	return queueMapping{port: port, rxq: rxq, lcore: lcore}, nil
+}
+
+// readQueueMapFile reads a mapping per line, empty lines and lines starting with # are skipped
+func readQueueMapFile(path string) (queueMapArray, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var q queueMapArray
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		m, err := parseQueueMapping(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", path, n, err)
+		}
+		q = append(q, m)
+	}
+	return q, scanner.Err()
+}
+
+// streamLcores returns the lcore of every forwarding stream, in the order testpmd creates the
+// streams: queue 0 of every port, then queue 1 of every port... Each stream needs its own lcore.
+func streamLcores(q queueMapArray, ports int, queues int) ([]int, error) {
+	lcores := make([]int, ports*queues)
+	for i := range lcores {
+		lcores[i] = -1
+	}
+	seen := make(map[int]string)
+	for _, m := range q {
+		if m.port >= ports || m.rxq >= queues {
+			return nil, fmt.Errorf("queue mapping %d:%d@%d is out of range, %d ports with %d queues", m.port, m.rxq, m.lcore, ports, queues)
+		}
+		name := fmt.Sprintf("%d:%d", m.port, m.rxq)
+		if other, ok := seen[m.lcore]; ok {
+			return nil, fmt.Errorf("lcore %d is mapped to both %s and %s", m.lcore, other, name)
+		}
+		seen[m.lcore] = name
+		i := m.rxq*ports + m.port
+		if lcores[i] >= 0 {
+			return nil, fmt.Errorf("queue %s is mapped more than once", name)
+		}
+		lcores[i] = m.lcore
+	}
+	for i, lcore := range lcores {
+		if lcore < 0 {
+			return nil, fmt.Errorf("queue %d:%d has no lcore, every queue needs a mapping", i%ports, i/ports)
+		}
+	}
+	return lcores, nil
+}
+
+// parseFwdConfig parses the output of "show config fwd"
+func parseFwdConfig(output string) (*pb.ForwardingConfig, error) {
+	config := &pb.ForwardingConfig{}
+	var lcore, socket int32
+	found, inLcore := false, false
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if m := fwdConfigHeaderRE.FindStringSubmatch(line); m != nil {
+			found = true
+			config.Mode = m[1]
+			config.NumPorts = atoi32(m[2])
+			config.NumCores = atoi32(m[3])
+			config.NumStreams = atoi32(m[4])
+		} else if m := fwdConfigLcoreRE.FindStringSubmatch(line); m != nil {
+			lcore, socket = atoi32(m[1]), atoi32(m[2])
+			inLcore = true
+		} else if m := fwdConfigStreamRE.FindStringSubmatch(line); m != nil {
+			if !inLcore {
+				return nil, fmt.Errorf("forwarding stream %q before its logical core", line)
+			}
+			config.FwdStreams = append(config.FwdStreams, &pb.FwdStream{
+				Lcore:       lcore,
+				LcoreSocket: socket,
+				RxPort:      atoi32(m[1]),
+				RxQueue:     atoi32(m[2]),
+				TxPort:      atoi32(m[4]),
+				TxQueue:     atoi32(m[5]),
+				PeerMac:     m[7],
+			})
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("no forwarding config found in testpmd output")
+	}
+	return config, nil
+}
+
+func atoi32(s string) int32 {
+	i, _ := strconv.ParseInt(s, 10, 32)
+	return int32(i)
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/redhat-nfvpe/container-perf-tools/standalone-testpmd/rpc"
+)
+
+func TestParseQueueMapping(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    queueMapping
+		wantErr bool
+	}{
+		{in: "0:0@2", want: queueMapping{port: 0, rxq: 0, lcore: 2}},
+		{in: " 1:3@14 ", want: queueMapping{port: 1, rxq: 3, lcore: 14}},
+		{in: "1:3", wantErr: true},
+		{in: "1@3", wantErr: true},
+		{in: "-1:0@2", wantErr: true},
+		{in: "a:b@c", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		m, err := parseQueueMapping(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: error %v, want error %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && m != tt.want {
+			t.Errorf("%q: %+v, want %+v", tt.in, m, tt.want)
+		}
+	}
+}
+
+func TestReadQueueMapFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "queuemap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString("# port:rxq@lcore\n0:0@2\n\n  1:0@3\n")
+	f.Close()
+	q, err := readQueueMapFile(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (queueMapArray{{0, 0, 2}, {1, 0, 3}}); !reflect.DeepEqual(q, want) {
+		t.Errorf("queue map %+v, want %+v", q, want)
+	}
+
+	if err := ioutil.WriteFile(f.Name(), []byte("0:0@2\n# next\n1:0-3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readQueueMapFile(f.Name()); err == nil || !strings.Contains(err.Error(), f.Name()+":3:") {
+		t.Errorf("error %v, want one for line 3", err)
+	}
+}
+
+func TestStreamLcores(t *testing.T) {
+	tests := []struct {
+		name   string
+		q      queueMapArray
+		ports  int
+		queues int
+		want   []int
+		// part of the error, none if empty
+		wantErr string
+	}{
+		{
+			name: "one queue", q: queueMapArray{{1, 0, 5}, {0, 0, 4}}, ports: 2, queues: 1,
+			want: []int{4, 5},
+		},
+		{
+			// queue 0 of every port, then queue 1
+			name: "stream order", q: queueMapArray{{0, 0, 2}, {0, 1, 3}, {1, 0, 4}, {1, 1, 5}}, ports: 2, queues: 2,
+			want: []int{2, 4, 3, 5},
+		},
+		{
+			name: "port out of range", q: queueMapArray{{2, 0, 2}}, ports: 2, queues: 1,
+			wantErr: "2:0@2 is out of range",
+		},
+		{
+			name: "queue out of range", q: queueMapArray{{0, 1, 2}}, ports: 2, queues: 1,
+			wantErr: "0:1@2 is out of range",
+		},
+		{
+			name: "shared lcore", q: queueMapArray{{0, 0, 2}, {1, 0, 2}}, ports: 2, queues: 1,
+			wantErr: "lcore 2 is mapped to both 0:0 and 1:0",
+		},
+		{
+			name: "queue mapped twice", q: queueMapArray{{0, 0, 2}, {0, 0, 3}}, ports: 2, queues: 1,
+			wantErr: "queue 0:0 is mapped more than once",
+		},
+		{
+			name: "queue without lcore", q: queueMapArray{{0, 0, 2}}, ports: 2, queues: 1,
+			wantErr: "queue 1:0 has no lcore",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lcores, err := streamLcores(tt.q, tt.ports, tt.queues)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(lcores, tt.want) {
+				t.Errorf("lcores %v, want %v", lcores, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFwdConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   *pb.ForwardingConfig
+		// part of the error, none if empty
+		wantErr string
+	}{
+		{
+			name: "two lcores",
+			output: `show config fwd
+io packet forwarding - ports=2 - cores=2 - streams=2 - NUMA support enabled, MP allocation mode: native
+Logical Core 2 (socket 0) forwards packets on 1 streams:
+  RX P=0/Q=0 (socket 0) -> TX P=1/Q=0 (socket 0) peer=02:00:00:00:01:01
+Logical Core 35 (socket 1) forwards packets on 1 streams:
+  RX P=1/Q=0 (socket 1) -> TX P=0/Q=0 (socket 0) peer=02:00:00:00:01:00
+
+testpmd> `,
+			want: &pb.ForwardingConfig{Mode: "io", NumPorts: 2, NumCores: 2, NumStreams: 2, FwdStreams: []*pb.FwdStream{
+				{Lcore: 2, LcoreSocket: 0, RxPort: 0, RxQueue: 0, TxPort: 1, TxQueue: 0, PeerMac: "02:00:00:00:01:01"},
+				{Lcore: 35, LcoreSocket: 1, RxPort: 1, RxQueue: 0, TxPort: 0, TxQueue: 0, PeerMac: "02:00:00:00:01:00"},
+			}},
+		},
+		{
+			name: "streams sharing an lcore",
+			output: `macswap packet forwarding - ports=1 - cores=1 - streams=2 - NUMA support enabled, MP allocation mode: native
+Logical Core 3 (socket -1) forwards packets on 2 streams:
+  RX P=0/Q=0 (socket -1) -> TX P=0/Q=0 (socket -1) peer=02:00:00:00:01:00
+  RX P=0/Q=1 (socket -1) -> TX P=0/Q=1 (socket -1) peer=02:00:00:00:01:00
+`,
+			want: &pb.ForwardingConfig{Mode: "macswap", NumPorts: 1, NumCores: 1, NumStreams: 2, FwdStreams: []*pb.FwdStream{
+				{Lcore: 3, LcoreSocket: -1, RxPort: 0, RxQueue: 0, TxPort: 0, TxQueue: 0, PeerMac: "02:00:00:00:01:00"},
+				{Lcore: 3, LcoreSocket: -1, RxPort: 0, RxQueue: 1, TxPort: 0, TxQueue: 1, PeerMac: "02:00:00:00:01:00"},
+			}},
+		},
+		{
+			name:   "no lcores",
+			output: "rxonly packet forwarding - ports=2 - cores=0 - streams=2 - NUMA support enabled\n",
+			want:   &pb.ForwardingConfig{Mode: "rxonly", NumPorts: 2, NumCores: 0, NumStreams: 2},
+		},
+		{
+			name:    "no header",
+			output:  "Logical Core 2 (socket 0) forwards packets on 1 streams:\n",
+			wantErr: "no forwarding config found",
+		},
+		{
+			name:    "rejected",
+			output:  "Bad arguments\ntestpmd> ",
+			wantErr: "no forwarding config found",
+		},
+		{
+			name: "stream without lcore",
+			output: `io packet forwarding - ports=2 - cores=1 - streams=1 - NUMA support enabled
+  RX P=0/Q=0 (socket 0) -> TX P=1/Q=0 (socket 0) peer=02:00:00:00:01:01
+`,
+			wantErr: "before its logical core",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := parseFwdConfig(tt.output)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(config, tt.want) {
+				t.Errorf("config %v, want %v", config, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"net"
+
+	pb "github.com/redhat-nfvpe/container-perf-tools/standalone-testpmd/rpc"
+)
+
+// testpmd "set fwd" names of the forwarding engines
+var fwdEngineNames = map[pb.FwdEngine]string{
+	pb.FwdEngine_FWD_IO:          "io",
+	pb.FwdEngine_FWD_MAC:         "mac",
+	pb.FwdEngine_FWD_MACSWAP:     "macswap",
+	pb.FwdEngine_FWD_FLOWGEN:     "flowgen",
+	pb.FwdEngine_FWD_RXONLY:      "rxonly",
+	pb.FwdEngine_FWD_TXONLY:      "txonly",
+	pb.FwdEngine_FWD_CSUM:        "csum",
+	pb.FwdEngine_FWD_ICMPECHO:    "icmpecho",
+	pb.FwdEngine_FWD_5TUPLE_SWAP: "5tswap",
+	pb.FwdEngine_FWD_NOISY:       "noisy",
+}
+
+// noisySettings are the testpmd startup options of the noisy engine, they can't be changed
+// once testpmd runs. 0 leaves the testpmd default.
+type noisySettings struct {
+	txSwBufferSize      int
+	txSwBufferFlushtime int
+	lkupMemory          int
+	lkupNumWrites       int
+	lkupNumReads        int
+	lkupNumReadsWrites  int
+}
+
+type noisyOption struct {
+	name  string
+	value int
+}
+
+func (n noisySettings) options() []noisyOption {
+	return []noisyOption{
+		{"noisy-tx-sw-buffer-size", n.txSwBufferSize},
+		{"noisy-tx-sw-buffer-flushtime", n.txSwBufferFlushtime},
+		{"noisy-lkup-memory", n.lkupMemory},
+		{"noisy-lkup-num-writes", n.lkupNumWrites},
+		{"noisy-lkup-num-reads", n.lkupNumReads},
+		{"noisy-lkup-num-reads-writes", n.lkupNumReadsWrites},
+	}
+}
+
+// args returns the testpmd options of the settings that are set
+func (n noisySettings) args() []string {
+	var args []string
+	for _, o := range n.options() {
+		if o.value != 0 {
+			args = append(args, fmt.Sprintf("--%s=%d", o.name, o.value))
+		}
+	}
+	return args
+}
+
+// fwdModeSetup returns the testpmd mode name and the commands that apply the mode parameters
+func fwdModeSetup(in *pb.ForwardingMode) (string, []string, error) {
+	mode, ok := fwdEngineNames[in.Engine]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown forwarding engine %v", in.Engine)
+	}
+	var cmds []string
+	var err error
+	switch params := in.Params.(type) {
+	case nil:
+	case *pb.ForwardingMode_TxPacket:
+		cmds, err = fwdModeCmds(mode, params.TxPacket.SegmentLengths, params.TxPacket.Burst, nil)
+	case *pb.ForwardingMode_PeerMacs:
+		cmds, err = fwdModeCmds(mode, nil, 0, params.PeerMacs.PeerMac)
+	default:
+		return "", nil, fmt.Errorf("unknown parameters for %s mode", mode)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	return mode, cmds, nil
+}
+
+// fwdModeCmds returns the commands that apply the parameters of a mode, the tx packet
+// parameters are for txonly and flowgen, the peer macs for mac
+func fwdModeCmds(mode string, segmentLengths []uint32, burst uint32, peerMacs []*pb.PeerMac) ([]string, error) {
+	var cmds []string
+	if len(segmentLengths) > 0 || burst != 0 {
+		if mode != "txonly" && mode != "flowgen" {
+			return nil, fmt.Errorf("tx packet parameters are not used by %s mode", mode)
+		}
+		if len(segmentLengths) > 0 {
+			lengths := make([]int, len(segmentLengths))
+			for i, l := range segmentLengths {
+				lengths[i] = int(l)
+			}
+			cmds = append(cmds, "set txpkts "+intToString(lengths, ","))
+		}
+		if burst != 0 {
+			cmds = append(cmds, fmt.Sprintf("set burst %d", burst))
+		}
+	}
+	if len(peerMacs) > 0 && mode != "mac" {
+		return nil, fmt.Errorf("peer macs are not used by %s mode", mode)
+	}
+	for _, peerMac := range peerMacs {
+		if _, err := net.ParseMAC(peerMac.MacAddress); err != nil {
+			return nil, err
+		}
+		cmds = append(cmds, fmt.Sprintf("set eth-peer %d %s", peerMac.PortNum, peerMac.MacAddress))
+	}
+	return cmds, nil
+}
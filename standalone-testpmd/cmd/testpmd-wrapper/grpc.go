@@ -6,37 +6,42 @@ import (
 	"log"
 	"regexp"
 	"strconv"
+	"time"
 
 	empty "github.com/golang/protobuf/ptypes/empty"
 	pb "github.com/redhat-nfvpe/container-perf-tools/standalone-testpmd/rpc"
 )
 
+// lower bound of the StreamThroughput sampling interval, to leave the session to other callers
+const minThroughputInterval = 100 * time.Millisecond
+
 type server struct {
 	pb.UnimplementedTestpmdServer
+	t testpmdOps
 }
 
 func (s *server) GetMacAddress(ctx context.Context, in *pb.Pci) (*pb.MacAddress, error) {
 	pci := normalizePci(in.PciAddress)
 	log.Printf("GetMacAddress: PCI %v\n", pci)
-	mac, err := pTestpmd.getMacAddress(pci)
+	mac, err := s.t.getMacAddress(ctx, pci)
 	if err != nil {
-		return &pb.MacAddress{MacAddress: ""}, err
+		return nil, grpcError(err)
 	}
 	return &pb.MacAddress{MacAddress: mac}, nil
 }
 
 func (s *server) IcmpMode(ctx context.Context, in *empty.Empty) (*pb.Success, error) {
 	log.Printf("IcmpMode:\n")
-	if err := pTestpmd.icmpMode(); err != nil {
-		return &pb.Success{Success: false}, err
+	if err := s.t.icmpMode(ctx); err != nil {
+		return nil, grpcError(err)
 	}
 	return &pb.Success{Success: true}, nil
 }
 
 func (s *server) IoMode(ctx context.Context, in *empty.Empty) (*pb.Success, error) {
 	log.Printf("IoMode:\n")
-	if err := pTestpmd.ioMode(); err != nil {
-		return &pb.Success{Success: false}, err
+	if err := s.t.ioMode(ctx); err != nil {
+		return nil, grpcError(err)
 	}
 	return &pb.Success{Success: true}, nil
 }
@@ -45,12 +50,24 @@ func (s *server) MacMode(ctx context.Context, in *pb.PeerMacs) (*pb.Success, err
 	log.Printf("MacMode:\n")
 	for _, peerMac := range in.PeerMac {
 		log.Printf("port %d, peer mac %s\n", peerMac.PortNum, peerMac.MacAddress)
-		if err := pTestpmd.setPeerMac(peerMac.PortNum, peerMac.MacAddress); err != nil {
-			return &pb.Success{Success: false}, err
+		if err := s.t.setPeerMac(ctx, peerMac.PortNum, peerMac.MacAddress); err != nil {
+			return nil, grpcError(err)
 		}
 	}
-	if err := pTestpmd.macMode(); err != nil {
-		return &pb.Success{Success: false}, err
+	if err := s.t.macMode(ctx); err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.Success{Success: true}, nil
+}
+
+func (s *server) SetForwardingMode(ctx context.Context, in *pb.ForwardingMode) (*pb.Success, error) {
+	log.Printf("SetForwardingMode: %v\n", in)
+	mode, cmds, err := fwdModeSetup(in)
+	if err != nil {
+		return nil, grpcError(invalidArgument("%v", err))
+	}
+	if err := s.t.setFwdModeWith(ctx, mode, cmds); err != nil {
+		return nil, grpcError(err)
 	}
 	return &pb.Success{Success: true}, nil
 }
@@ -58,66 +75,181 @@ func (s *server) MacMode(ctx context.Context, in *pb.PeerMacs) (*pb.Success, err
 func (s *server) GetPortInfo(ctx context.Context, in *pb.Pci) (*pb.PortInfo, error) {
 	pciAddr := normalizePci(in.PciAddress)
 	log.Printf("GetPortInfo: %s\n", pciAddr)
-	output, err := pTestpmd.getPortInfo(pciAddr)
+	output, err := s.t.getPortInfo(ctx, pciAddr)
 	if err != nil {
-		return &pb.PortInfo{}, err
-	}
-	numRe := regexp.MustCompile(`Port id:\s*(\S+)`)
-	portNum := numRe.FindStringSubmatch(output)[1]
-	macRe := regexp.MustCompile(`MAC address:\s*(\S+)`)
-	mac := macRe.FindStringSubmatch(output)[1]
-	pciRe := regexp.MustCompile(`Device name:\s*(\S+)`)
-	pci := pciRe.FindStringSubmatch(output)[1]
-	if portNum == "" || mac == "" || pci == "" {
-		return &pb.PortInfo{}, fmt.Errorf("failed to find port info")
-	}
-	num, err := strconv.Atoi(portNum)
+		return nil, grpcError(err)
+	}
+	info, err := parsePortInfo(output)
 	if err != nil {
-		return &pb.PortInfo{}, err
+		return nil, grpcError(unexpectedOutput("show device info "+pciAddr, output, err))
 	}
-	num32 := int32(num)
-	return &pb.PortInfo{PortNum: num32, MacAddress: mac, PciAddress: pci}, nil
+	return info, nil
 }
 
-func (s *server) ListPorts(ctx context.Context, in *empty.Empty) (*pb.PortList, error) {
-	log.Printf("ListPorts:\n")
-	output, err := pTestpmd.listPorts()
+var (
+	portNumRE = regexp.MustCompile(`Port id:\s*(\d+)`)
+	portMacRE = regexp.MustCompile(`MAC address:\s*(\S+)`)
+	portPciRE = regexp.MustCompile(`Device name:\s*(\S+)`)
+)
+
+// parsePortInfo parses the output of "show device info <pci>"
+func parsePortInfo(output string) (*pb.PortInfo, error) {
+	num := portNumRE.FindStringSubmatch(output)
+	mac := portMacRE.FindStringSubmatch(output)
+	pci := portPciRE.FindStringSubmatch(output)
+	if num == nil || mac == nil || pci == nil {
+		return nil, fmt.Errorf("failed to find port info")
+	}
+	i, err := strconv.Atoi(num[1])
 	if err != nil {
-		return &pb.PortList{}, err
-	}
-	var portInfoArray []*pb.PortInfo
-	r := regexp.MustCompile(`Port id:\s*(\S+)\s*MAC address:\s*(\S+)\s*Device name:\s*(\S+)`)
-	matches := r.FindAllStringSubmatch(output, -1)
-	log.Printf("matches: %v\n", matches)
-	for _, v := range matches {
-		portInfo := &pb.PortInfo{}
-		log.Printf("%s, %s:%s:%s\n", v[0], v[1], v[2], v[3])
+		return nil, err
+	}
+	return &pb.PortInfo{PortNum: int32(i), MacAddress: mac[1], PciAddress: pci[1]}, nil
+}
+
+var portListRE = regexp.MustCompile(`Port id:\s*(\S+)\s*MAC address:\s*(\S+)\s*Device name:\s*(\S+)`)
+
+// parsePortList parses the output of "show device info all"
+func parsePortList(output string) ([]*pb.PortInfo, error) {
+	var ports []*pb.PortInfo
+	for _, v := range portListRE.FindAllStringSubmatch(output, -1) {
 		i, err := strconv.Atoi(v[1])
 		if err != nil {
-			return &pb.PortList{}, err
+			return nil, err
 		}
-		portInfo.PortNum = int32(i)
-		portInfo.MacAddress = v[2]
-		portInfo.PciAddress = v[3]
-		portInfoArray = append(portInfoArray, portInfo)
+		ports = append(ports, &pb.PortInfo{PortNum: int32(i), MacAddress: v[2], PciAddress: v[3]})
+	}
+	return ports, nil
+}
+
+func (s *server) ListPorts(ctx context.Context, in *empty.Empty) (*pb.PortList, error) {
+	log.Printf("ListPorts:\n")
+	output, err := s.t.listPorts(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	ports, err := parsePortList(output)
+	if err != nil {
+		return nil, grpcError(unexpectedOutput("show device info all", output, err))
 	}
-	return &pb.PortList{PortInfo: portInfoArray}, nil
+	return &pb.PortList{PortInfo: ports}, nil
 }
 
 func (s *server) GetFwdInfo(ctx context.Context, in *empty.Empty) (*pb.FwdInfo, error) {
 	log.Printf("GetFwdInfo:\n")
-	output, err := pTestpmd.getFwdInfo()
+	output, err := s.t.getFwdInfo(ctx)
 	if err != nil {
-		return &pb.FwdInfo{}, err
+		return nil, grpcError(err)
 	}
 	return &pb.FwdInfo{FwdInfoStr: output}, nil
 }
 
+func (s *server) GetFwdStats(ctx context.Context, in *empty.Empty) (*pb.FwdStats, error) {
+	log.Printf("GetFwdStats:\n")
+	stats, err := s.t.getFwdStats(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return stats, nil
+}
+
+func (s *server) StreamThroughput(in *pb.ThroughputRequest, stream pb.Testpmd_StreamThroughputServer) error {
+	return grpcError(watchThroughput(stream.Context(), s.t, in.IntervalMs, stream.Send))
+}
+
+// watchThroughput sends the rates of the port counters every intervalMs until ctx is done
+func watchThroughput(ctx context.Context, t testpmdOps, intervalMs uint32, send func(*pb.Throughput) error) error {
+	interval := time.Duration(intervalMs) * time.Millisecond
+	if interval == 0 {
+		interval = time.Second
+	} else if interval < minThroughputInterval {
+		interval = minThroughputInterval
+	}
+	log.Printf("watchThroughput: interval %v\n", interval)
+	prev, err := t.getPortCounters(ctx)
+	if err != nil {
+		return err
+	}
+	prevTime := time.Now()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("watchThroughput: stopped, %v\n", ctx.Err())
+			return nil
+		case <-ticker.C:
+		}
+		cur, err := t.getPortCounters(ctx)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		elapsed := now.Sub(prevTime)
+		prevPorts := make(map[int32]*portCounters)
+		for _, p := range prev {
+			prevPorts[p.portNum] = p
+		}
+		sample := &pb.Throughput{
+			TimestampMs: now.UnixNano() / int64(time.Millisecond),
+			IntervalMs:  uint32(elapsed / time.Millisecond),
+		}
+		for _, c := range cur {
+			p, ok := prevPorts[c.portNum]
+			if !ok {
+				p = &portCounters{portNum: c.portNum}
+			}
+			sample.PortThroughput = append(sample.PortThroughput, portThroughput(c, p, elapsed))
+		}
+		if err := send(sample); err != nil {
+			return err
+		}
+		prev, prevTime = cur, now
+	}
+}
+
 func (s *server) ClearFwdInfo(ctx context.Context, in *empty.Empty) (*pb.Success, error) {
 	log.Printf("ClearFwdInfo:\n")
-	_, err := pTestpmd.clearFwdInfo()
+	_, err := s.t.clearFwdInfo(ctx)
 	if err != nil {
-		return &pb.Success{Success: false}, err
+		return nil, grpcError(err)
 	}
 	return &pb.Success{Success: true}, nil
 }
+
+func (s *server) GetCorePlan(ctx context.Context, in *empty.Empty) (*pb.CorePlan, error) {
+	log.Printf("GetCorePlan:\n")
+	plan := s.t.getCorePlan()
+	if plan == nil {
+		return nil, grpcError(fmt.Errorf("no core plan: %w", errNotRunning))
+	}
+	out := &pb.CorePlan{
+		Policy:    plan.policy,
+		MainLcore: plan.lcoreInfo(plan.mainLcore),
+	}
+	for _, node := range plan.portNodes {
+		out.PortNumaNodes = append(out.PortNumaNodes, int32(node))
+	}
+	for _, cpu := range plan.pmdLcores {
+		out.PmdLcores = append(out.PmdLcores, plan.lcoreInfo(cpu))
+	}
+	return out, nil
+}
+
+func (s *server) GetForwardingConfig(ctx context.Context, in *empty.Empty) (*pb.ForwardingConfig, error) {
+	log.Printf("GetForwardingConfig:\n")
+	config, err := s.t.getFwdConfig(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return config, nil
+}
+
+func (s *server) GetDpdkVersion(ctx context.Context, in *empty.Empty) (*pb.DpdkVersion, error) {
+	log.Printf("GetDpdkVersion:\n")
+	version, release, path := s.t.getDpdkVersion()
+	if release == nil {
+		return nil, grpcError(fmt.Errorf("DPDK version not detected: %w", errNotRunning))
+	}
+	return &pb.DpdkVersion{Version: version, Release: release.String(), TestpmdPath: path}, nil
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	empty "github.com/golang/protobuf/ptypes/empty"
+	pb "github.com/redhat-nfvpe/container-perf-tools/standalone-testpmd/rpc"
+	pbv2 "github.com/redhat-nfvpe/container-perf-tools/standalone-testpmd/rpc/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestServer runs both grpc services over an in-memory connection on a simulated testpmd
+// with two pci ports, the returned function stops everything
+func newTestServer(t *testing.T) (*grpc.ClientConn, func()) {
+	t.Helper()
+	tp, stopTestpmd := newSimTestpmd(t)
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	pb.RegisterTestpmdServer(s, &server{t: tp})
+	pbv2.RegisterTestpmdServer(s, &serverV2{t: tp, bindings: map[string]*pciInfo{}})
+	go s.Serve(lis)
+	dial := func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithContextDialer(dial), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conn, func() {
+		conn.Close()
+		s.Stop()
+		stopTestpmd()
+	}
+}
+
+func checkCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+	if got := status.Code(err); got != want {
+		t.Errorf("got %v (%v), want %v", got, err, want)
+	}
+}
+
+func TestServerRoundTrip(t *testing.T) {
+	conn, stop := newTestServer(t)
+	defer stop()
+	c := pb.NewTestpmdClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	list, err := c.ListPorts(ctx, &empty.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.PortInfo) != 2 || list.PortInfo[1].PciAddress != "0000:86:00.1" {
+		t.Fatalf("unexpected ports %v", list.PortInfo)
+	}
+	// the short form of the address is normalized
+	mac, err := c.GetMacAddress(ctx, &pb.Pci{PciAddress: "86:00.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mac.MacAddress != "02:00:00:00:00:01" {
+		t.Errorf("mac of port 1 is %s", mac.MacAddress)
+	}
+	_, err = c.GetMacAddress(ctx, &pb.Pci{PciAddress: "0000:87:00.0"})
+	checkCode(t, err, codes.InvalidArgument)
+
+	peers := &pb.PeerMacs{PeerMac: []*pb.PeerMac{{PortNum: 0, MacAddress: "02:00:00:00:02:00"}}}
+	if _, err := c.MacMode(ctx, peers); err != nil {
+		t.Fatal(err)
+	}
+	config, err := c.GetForwardingConfig(ctx, &empty.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Mode != "mac" {
+		t.Errorf("mode is %s after MacMode", config.Mode)
+	}
+	_, err = c.SetForwardingMode(ctx, &pb.ForwardingMode{Engine: pb.FwdEngine(100)})
+	checkCode(t, err, codes.InvalidArgument)
+
+	stats, err := c.GetFwdStats(ctx, &empty.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats.PortStats) != 2 || stats.Accumulated == nil {
+		t.Errorf("unexpected stats %v", stats)
+	}
+}
+
+func TestServerV2RoundTrip(t *testing.T) {
+	conn, stop := newTestServer(t)
+	defer stop()
+	c := pbv2.NewTestpmdClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	st, err := c.GetStatus(ctx, &pbv2.GetStatusRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Engine != pbv2.Engine_ENGINE_IO || st.Running || len(st.Ports) != 2 || st.PortConfig.GetMtu() != 1500 {
+		t.Fatalf("unexpected status after start %v", st)
+	}
+	fwd, err := c.UpdateForwardingConfig(ctx, &pbv2.ForwardingConfig{Engine: pbv2.Engine_ENGINE_MACSWAP})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fwd.Mode != "macswap" || !fwd.Running || len(fwd.Streams) != 2 {
+		t.Errorf("unexpected forwarding config %v", fwd)
+	}
+	_, err = c.UpdateForwardingConfig(ctx, &pbv2.ForwardingConfig{Engine: pbv2.Engine_ENGINE_IO, Mode: "mac"})
+	checkCode(t, err, codes.InvalidArgument)
+
+	watch, err := c.WatchThroughput(ctx, &pbv2.WatchThroughputRequest{IntervalMs: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		tp, err := watch.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tp.Ports) != 2 {
+			t.Fatalf("throughput of %d ports", len(tp.Ports))
+		}
+	}
+
+	cfg, err := c.ConfigurePorts(ctx, &pbv2.PortConfig{Mtu: 9000, RxRingSize: 1024})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Mtu != 9000 || cfg.RxRingSize != 1024 || cfg.TxRingSize != 512 {
+		t.Errorf("unexpected port config %v", cfg)
+	}
+	// rejected by the port, the previous settings stay
+	_, err = c.ConfigurePorts(ctx, &pbv2.PortConfig{Mtu: 65000})
+	checkCode(t, err, codes.InvalidArgument)
+	st, err = c.GetStatus(ctx, &pbv2.GetStatusRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.PortConfig.Mtu != 9000 || !st.Running || st.Mode != "macswap" {
+		t.Errorf("unexpected status after a rejected config %v", st)
+	}
+
+	stats, err := c.StopForwarding(ctx, &pbv2.StopForwardingRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats.Ports) != 2 || stats.Total.GetRxPackets() == 0 {
+		t.Errorf("unexpected stats on stop %v", stats)
+	}
+	if _, err := c.StartForwarding(ctx, &pbv2.StartForwardingRequest{}); err != nil {
+		t.Fatal(err)
+	}
+}
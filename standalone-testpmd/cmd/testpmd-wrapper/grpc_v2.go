@@ -0,0 +1,303 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	pb "github.com/redhat-nfvpe/container-perf-tools/standalone-testpmd/rpc"
+	pbv2 "github.com/redhat-nfvpe/container-perf-tools/standalone-testpmd/rpc/v2"
+)
+
+// serverV2 serves the testpmd.v2 api on the same testpmd operations as the v1 server
+type serverV2 struct {
+	pbv2.UnimplementedTestpmdServer
+	t testpmdOps
+	// drivers of the pci ports, by address
+	bindings map[string]*pciInfo
+}
+
+// testpmd "set fwd" names of the v2 engines
+var engineNames = map[pbv2.Engine]string{
+	pbv2.Engine_ENGINE_IO:       "io",
+	pbv2.Engine_ENGINE_MAC:      "mac",
+	pbv2.Engine_ENGINE_MACSWAP:  "macswap",
+	pbv2.Engine_ENGINE_FLOWGEN:  "flowgen",
+	pbv2.Engine_ENGINE_RXONLY:   "rxonly",
+	pbv2.Engine_ENGINE_TXONLY:   "txonly",
+	pbv2.Engine_ENGINE_CSUM:     "csum",
+	pbv2.Engine_ENGINE_ICMPECHO: "icmpecho",
+	pbv2.Engine_ENGINE_5TSWAP:   "5tswap",
+	pbv2.Engine_ENGINE_NOISY:    "noisy",
+}
+
+// engineOf returns the engine of a testpmd mode, unspecified for the modes without one
+func engineOf(mode string) pbv2.Engine {
+	for engine, name := range engineNames {
+		if name == mode {
+			return engine
+		}
+	}
+	return pbv2.Engine_ENGINE_UNSPECIFIED
+}
+
+func portV2(p *pb.PortInfo) *pbv2.Port {
+	return &pbv2.Port{Id: p.PortNum, Name: p.PciAddress, MacAddress: p.MacAddress}
+}
+
+func portStatsV2(p *pb.PortFwdStats) *pbv2.PortStats {
+	if p == nil {
+		return nil
+	}
+	return &pbv2.PortStats{
+		PortId:           p.PortNum,
+		RxPackets:        p.RxPackets,
+		RxDropped:        p.RxDropped,
+		RxTotal:          p.RxTotal,
+		TxPackets:        p.TxPackets,
+		TxDropped:        p.TxDropped,
+		TxTotal:          p.TxTotal,
+		RxBadIpCsum:      p.RxBadIpCsum,
+		RxBadL4Csum:      p.RxBadL4Csum,
+		RxBadOuterL4Csum: p.RxBadOuterL4Csum,
+	}
+}
+
+func statsV2(stats *pb.FwdStats) *pbv2.Stats {
+	out := &pbv2.Stats{Total: portStatsV2(stats.Accumulated)}
+	for _, p := range stats.PortStats {
+		out.Ports = append(out.Ports, portStatsV2(p))
+	}
+	return out
+}
+
+func throughputV2(t *pb.Throughput) *pbv2.Throughput {
+	out := &pbv2.Throughput{TimestampMs: t.TimestampMs, IntervalMs: t.IntervalMs}
+	for _, p := range t.PortThroughput {
+		out.Ports = append(out.Ports, &pbv2.PortThroughput{
+			PortId:     p.PortNum,
+			RxPps:      p.RxPps,
+			TxPps:      p.TxPps,
+			RxBps:      p.RxBps,
+			TxBps:      p.TxBps,
+			RxDropPps:  p.RxDropPps,
+			TxErrorPps: p.TxErrorPps,
+		})
+	}
+	return out
+}
+
+func lcoreV2(l *pb.Lcore) *pbv2.Lcore {
+	return &pbv2.Lcore{Id: l.Id, NumaNode: l.NumaNode, Siblings: l.Siblings, Local: l.Local}
+}
+
+func portConfigV2(p portSettings, mbufSize int) *pbv2.PortConfig {
+	return &pbv2.PortConfig{
+		RxQueues:   uint32(p.rxq),
+		TxQueues:   uint32(p.txq),
+		RxRingSize: uint32(p.rxd),
+		TxRingSize: uint32(p.txd),
+		MbufSize:   uint32(mbufSize),
+		Mtu:        uint32(p.mtu),
+	}
+}
+
+func (s *serverV2) ListPorts(ctx context.Context, in *pbv2.ListPortsRequest) (*pbv2.ListPortsResponse, error) {
+	log.Printf("v2 ListPorts:\n")
+	output, err := s.t.listPorts(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	ports, err := parsePortList(output)
+	if err != nil {
+		return nil, grpcError(unexpectedOutput("show device info all", output, err))
+	}
+	out := &pbv2.ListPortsResponse{}
+	for _, p := range ports {
+		out.Ports = append(out.Ports, portV2(p))
+	}
+	return out, nil
+}
+
+func (s *serverV2) GetPort(ctx context.Context, in *pbv2.GetPortRequest) (*pbv2.Port, error) {
+	name := normalizePci(in.Name)
+	log.Printf("v2 GetPort: %s\n", name)
+	output, err := s.t.getPortInfo(ctx, name)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	info, err := parsePortInfo(output)
+	if err != nil {
+		return nil, grpcError(unexpectedOutput("show device info "+name, output, err))
+	}
+	return portV2(info), nil
+}
+
+func (s *serverV2) GetForwardingConfig(ctx context.Context, in *pbv2.GetForwardingConfigRequest) (*pbv2.ForwardingConfig, error) {
+	log.Printf("v2 GetForwardingConfig:\n")
+	config, err := s.t.getFwdConfig(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	_, running, _ := s.t.getState()
+	out := &pbv2.ForwardingConfig{
+		Engine:   engineOf(config.Mode),
+		Mode:     config.Mode,
+		Running:  running,
+		NumCores: config.NumCores,
+	}
+	peers := make(map[int32]bool)
+	for _, f := range config.FwdStreams {
+		out.Streams = append(out.Streams, &pbv2.Stream{
+			Lcore:       f.Lcore,
+			LcoreSocket: f.LcoreSocket,
+			RxPort:      f.RxPort,
+			RxQueue:     f.RxQueue,
+			TxPort:      f.TxPort,
+			TxQueue:     f.TxQueue,
+			PeerMac:     f.PeerMac,
+		})
+		// testpmd only sends to the peer in mac mode
+		if config.Mode == "mac" && f.PeerMac != "" && !peers[f.TxPort] {
+			peers[f.TxPort] = true
+			out.PeerMacs = append(out.PeerMacs, &pbv2.PeerMac{PortId: f.TxPort, MacAddress: f.PeerMac})
+		}
+	}
+	return out, nil
+}
+
+func (s *serverV2) UpdateForwardingConfig(ctx context.Context, in *pbv2.ForwardingConfig) (*pbv2.ForwardingConfig, error) {
+	log.Printf("v2 UpdateForwardingConfig: %v\n", in)
+	mode := in.Mode
+	if in.Engine != pbv2.Engine_ENGINE_UNSPECIFIED {
+		name, ok := engineNames[in.Engine]
+		if !ok {
+			return nil, grpcError(invalidArgument("unknown forwarding engine %v", in.Engine))
+		}
+		if mode != "" && mode != name {
+			return nil, grpcError(invalidArgument("engine %v and mode %s don't match", in.Engine, mode))
+		}
+		mode = name
+	}
+	if mode == "" {
+		return nil, grpcError(invalidArgument("no engine or mode given"))
+	}
+	var peerMacs []*pb.PeerMac
+	for _, p := range in.PeerMacs {
+		peerMacs = append(peerMacs, &pb.PeerMac{PortNum: p.PortId, MacAddress: p.MacAddress})
+	}
+	cmds, err := fwdModeCmds(mode, in.TxPacket.GetSegmentLengths(), in.TxPacket.GetBurst(), peerMacs)
+	if err != nil {
+		return nil, grpcError(invalidArgument("%v", err))
+	}
+	if err := s.t.setFwdModeWith(ctx, mode, cmds); err != nil {
+		return nil, grpcError(err)
+	}
+	return s.GetForwardingConfig(ctx, &pbv2.GetForwardingConfigRequest{})
+}
+
+func (s *serverV2) StartForwarding(ctx context.Context, in *pbv2.StartForwardingRequest) (*pbv2.ForwardingConfig, error) {
+	log.Printf("v2 StartForwarding:\n")
+	if err := s.t.startFwd(ctx); err != nil {
+		return nil, grpcError(err)
+	}
+	return s.GetForwardingConfig(ctx, &pbv2.GetForwardingConfigRequest{})
+}
+
+func (s *serverV2) StopForwarding(ctx context.Context, in *pbv2.StopForwardingRequest) (*pbv2.Stats, error) {
+	log.Printf("v2 StopForwarding:\n")
+	stats, err := s.t.stopFwd(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return statsV2(stats), nil
+}
+
+func (s *serverV2) ConfigurePorts(ctx context.Context, in *pbv2.PortConfig) (*pbv2.PortConfig, error) {
+	log.Printf("v2 ConfigurePorts: %v\n", in)
+	run := s.t.getRunInfo()
+	if in.MbufSize != 0 && int(in.MbufSize) != run.mbufSize {
+		return nil, grpcError(invalidArgument("the mbuf size is set when testpmd starts, it is %d", run.mbufSize))
+	}
+	want := portSettings{
+		rxq: int(in.RxQueues),
+		txq: int(in.TxQueues),
+		rxd: int(in.RxRingSize),
+		txd: int(in.TxRingSize),
+		mtu: int(in.Mtu),
+	}
+	cur, err := s.t.configurePorts(ctx, want)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return portConfigV2(cur, run.mbufSize), nil
+}
+
+func (s *serverV2) GetStats(ctx context.Context, in *pbv2.GetStatsRequest) (*pbv2.Stats, error) {
+	log.Printf("v2 GetStats:\n")
+	stats, err := s.t.getFwdStats(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return statsV2(stats), nil
+}
+
+func (s *serverV2) ClearStats(ctx context.Context, in *pbv2.ClearStatsRequest) (*empty.Empty, error) {
+	log.Printf("v2 ClearStats:\n")
+	if _, err := s.t.clearFwdInfo(ctx); err != nil {
+		return nil, grpcError(err)
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *serverV2) WatchThroughput(in *pbv2.WatchThroughputRequest, stream pbv2.Testpmd_WatchThroughputServer) error {
+	send := func(t *pb.Throughput) error {
+		return stream.Send(throughputV2(t))
+	}
+	return grpcError(watchThroughput(stream.Context(), s.t, in.IntervalMs, send))
+}
+
+func (s *serverV2) GetStatus(ctx context.Context, in *pbv2.GetStatusRequest) (*pbv2.Status, error) {
+	log.Printf("v2 GetStatus:\n")
+	mode, running, start := s.t.getState()
+	run := s.t.getRunInfo()
+	version, release, path := s.t.getDpdkVersion()
+	out := &pbv2.Status{
+		Engine:      engineOf(mode),
+		Mode:        mode,
+		Running:     running,
+		Pid:         int32(run.pid),
+		FilePrefix:  run.filePrefix,
+		Cmdline:     run.cmdline,
+		DpdkVersion: version,
+		TestpmdPath: path,
+		PortConfig:  portConfigV2(run.ports, run.mbufSize),
+	}
+	if release != nil {
+		out.DpdkRelease = release.String()
+	}
+	if !start.IsZero() {
+		out.StartTime, _ = ptypes.TimestampProto(start)
+		out.Uptime = ptypes.DurationProto(time.Since(start))
+	}
+	if plan := s.t.getCorePlan(); plan != nil {
+		out.CorePolicy = plan.policy
+		out.MainLcore = lcoreV2(plan.lcoreInfo(plan.mainLcore))
+		for _, cpu := range plan.pmdLcores {
+			out.PmdLcores = append(out.PmdLcores, lcoreV2(plan.lcoreInfo(cpu)))
+		}
+	}
+	for i, name := range s.t.getPorts() {
+		port := &pbv2.PortBinding{PortId: int32(i), Name: name}
+		if info, ok := s.bindings[name]; ok {
+			port.PreviousDriver, port.Driver = info.driverPre, info.driverCur
+			if info.netdev != nil {
+				port.Netdev = info.netdev.Name
+			}
+		}
+		out.Ports = append(out.Ports, port)
+	}
+	return out, nil
+}
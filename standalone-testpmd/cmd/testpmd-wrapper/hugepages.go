@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// testpmd default mbuf data size
+	defaultMbufSize = 2176
+	// MB per numa node for what does not scale with the rings: EAL, the mempool caches, the
+	// mbufs testpmd allocates per pool on top of the descriptors
+	socketMemOverhead = 512
+	numaNodeDir       = "devices/system/node"
+	// the hugetlbfs testpmd allocates from
+	hugepagesDir = "/dev/hugepages"
+)
+
+var (
+	hugepagesDirRE        = regexp.MustCompile(`^hugepages-(\d+)kB$`)
+	pageSizeOptRE         = regexp.MustCompile(`^pagesize=(\d+)([kKmMgG])?`)
+	meminfoHugepagesizeRE = regexp.MustCompile(`Hugepagesize:\s*(\d+) kB`)
+)
+
+// socketMem returns the MB of memory testpmd needs on each numa node, indexed by node up to
+// the last node with a port. Every port needs an mbuf for each rx and tx descriptor of each queue.
+// vdev ports have no numa node, they are counted on the node of the first pci port or node 0.
+// extraMB is what else each port takes, like the lookup memory of the noisy engine.
+func socketMem(bus *pciBus, pci pciArray, vdevs int, queues int, ring int, mbufSize int, extraMB int) ([]int, error) {
+	perPort := uint64(queues)*uint64(2*ring)*uint64(mbufSize) + uint64(extraMB)<<20
+	need := []uint64{0}
+	vdevNode := 0
+	for i, p := range pci {
+		numa, err := bus.getNumaNode(p)
+		if err != nil {
+			return nil, err
+		}
+		// -1 when the platform has no numa
+		if numa < 0 {
+			numa = 0
+		}
+		for len(need) <= numa {
+			need = append(need, 0)
+		}
+		need[numa] += perPort
+		if i == 0 {
+			vdevNode = numa
+		}
+	}
+	need[vdevNode] += uint64(vdevs) * perPort
+	mem := make([]int, len(need))
+	for node, bytes := range need {
+		if bytes > 0 {
+			mem[node] = int((bytes+1<<20-1)>>20) + socketMemOverhead
+		}
+	}
+	return mem, nil
+}
+
+type hugepagePool struct {
+	sizeKB uint64
+	free   uint64
+}
+
+// freeHugepages returns the free huge pages of every page size of a numa node
+func freeHugepages(root string, node int) ([]hugepagePool, error) {
+	dir := filepath.Join(root, numaNodeDir, fmt.Sprintf("node%d", node), "hugepages")
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var pools []hugepagePool
+	for _, e := range entries {
+		m := hugepagesDirRE.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+		size, _ := strconv.ParseUint(m[1], 10, 64)
+		out, err := ioutil.ReadFile(filepath.Join(dir, e.Name(), "free_hugepages"))
+		if err != nil {
+			return nil, err
+		}
+		free, err := strconv.ParseUint(strings.TrimSpace(string(out)), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		pools = append(pools, hugepagePool{sizeKB: size, free: free})
+	}
+	sort.Slice(pools, func(i, j int) bool { return pools[i].sizeKB < pools[j].sizeKB })
+	return pools, nil
+}
+
+// hugetlbfsPageSizeKB returns the page size of the hugetlbfs mounted at dir, given /proc/mounts and
+// /proc/meminfo, 0 if there is none. A mount without pagesize option has the default size of meminfo.
+func hugetlbfsPageSizeKB(mounts string, meminfo string, dir string) uint64 {
+	for _, line := range strings.Split(mounts, "\n") {
+		f := strings.Fields(line)
+		if len(f) < 4 || f[1] != dir || f[2] != "hugetlbfs" {
+			continue
+		}
+		for _, opt := range strings.Split(f[3], ",") {
+			if m := pageSizeOptRE.FindStringSubmatch(opt); m != nil {
+				size, _ := strconv.ParseUint(m[1], 10, 64)
+				switch strings.ToUpper(m[2]) {
+				case "M":
+					size <<= 10
+				case "G":
+					size <<= 20
+				}
+				return size
+			}
+		}
+		if m := meminfoHugepagesizeRE.FindStringSubmatch(meminfo); m != nil {
+			size, _ := strconv.ParseUint(m[1], 10, 64)
+			return size
+		}
+	}
+	return 0
+}
+
+// hostHugepageSizeKB returns the page size of /dev/hugepages, where testpmd allocates from
+func hostHugepageSizeKB() uint64 {
+	mounts, _ := ioutil.ReadFile("/proc/mounts")
+	meminfo, _ := ioutil.ReadFile("/proc/meminfo")
+	return hugetlbfsPageSizeKB(string(mounts), string(meminfo), hugepagesDir)
+}
+
+// checkHugepages fails if a numa node has fewer free huge pages than mem asks for. EAL takes the
+// memory of --socket-mem from pages of one size, pageSizeKB, or the size with the most free memory
+// if it is 0.
+func checkHugepages(root string, mem []int, pageSizeKB uint64) error {
+	if _, err := os.Stat(filepath.Join(root, numaNodeDir)); os.IsNotExist(err) {
+		log.Printf("no numa nodes in sysfs, skip the huge page check")
+		return nil
+	}
+	for node, mb := range mem {
+		if mb == 0 {
+			continue
+		}
+		pools, err := freeHugepages(root, node)
+		if err != nil {
+			return fmt.Errorf("failed to read the huge pages of numa node %d: %v", node, err)
+		}
+		pool := hugepagePool{sizeKB: pageSizeKB}
+		var detail []string
+		for _, p := range pools {
+			detail = append(detail, fmt.Sprintf("%d free %dkB pages", p.free, p.sizeKB))
+			if p.sizeKB == pageSizeKB || pageSizeKB == 0 && p.free*p.sizeKB > pool.free*pool.sizeKB {
+				pool = p
+			}
+		}
+		freeMB := pool.free * pool.sizeKB >> 10
+		if freeMB < uint64(mb) {
+			return fmt.Errorf("numa node %d needs %d MB of %dkB huge pages but only %d MB are free (%s)",
+				node, mb, pool.sizeKB, freeMB, strings.Join(detail, ", "))
+		}
+		log.Printf("numa node %d: %d MB of %dkB huge pages needed, %d MB free", node, mb, pool.sizeKB, freeMB)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSocketMem(t *testing.T) {
+	tests := []struct {
+		name string
+		// numa node of each pci port
+		nodes    []int
+		vdevs    int
+		queues   int
+		ring     int
+		mbufSize int
+		extraMB  int
+		want     []int
+	}{
+		// 1024 mbufs of 2176 bytes are 2.125 MB, rounded up
+		{name: "one port", nodes: []int{0}, queues: 1, ring: 512, mbufSize: defaultMbufSize, want: []int{515}},
+		{name: "two nodes", nodes: []int{0, 1}, queues: 1, ring: 512, mbufSize: defaultMbufSize, want: []int{515, 515}},
+		{name: "second node only", nodes: []int{1, 1}, queues: 1, ring: 512, mbufSize: defaultMbufSize, want: []int{0, 517}},
+		{name: "no numa", nodes: []int{-1}, queues: 1, ring: 512, mbufSize: defaultMbufSize, want: []int{515}},
+		{name: "vdevs only", vdevs: 2, queues: 1, ring: 512, mbufSize: defaultMbufSize, want: []int{517}},
+		{name: "vdev on the node of the first port", nodes: []int{1}, vdevs: 1, queues: 1, ring: 512,
+			mbufSize: defaultMbufSize, want: []int{0, 517}},
+		{name: "queues and rings", nodes: []int{0}, queues: 4, ring: 2048, mbufSize: defaultMbufSize, want: []int{546}},
+		{name: "jumbo mbufs", nodes: []int{0}, queues: 1, ring: 512, mbufSize: 9216, want: []int{521}},
+		{name: "extra memory", nodes: []int{0}, queues: 1, ring: 512, mbufSize: defaultMbufSize, extraMB: 64, want: []int{579}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := newFakeSysfs()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.remove()
+			var pci pciArray
+			for i, node := range tt.nodes {
+				p := fmt.Sprintf("0000:86:00.%d", i)
+				if err := f.addDevice(p, "0x8086", "0x1572", node, "", ""); err != nil {
+					t.Fatal(err)
+				}
+				pci = append(pci, p)
+			}
+			mem, err := socketMem(f.bus(), pci, tt.vdevs, tt.queues, tt.ring, tt.mbufSize, tt.extraMB)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(mem, tt.want) {
+				t.Errorf("socket-mem %v, want %v", mem, tt.want)
+			}
+		})
+	}
+}
+
+func TestHugetlbfsPageSizeKB(t *testing.T) {
+	const meminfo = "MemTotal:       196608000 kB\nHugepagesize:       2048 kB\n"
+	tests := []struct {
+		name   string
+		mounts string
+		want   uint64
+	}{
+		{"1G pages", "hugetlbfs /dev/hugepages hugetlbfs rw,relatime,pagesize=1G 0 0", 1 << 20},
+		{"2M pages", "hugetlbfs /dev/hugepages hugetlbfs rw,relatime,pagesize=2M 0 0", 2048},
+		{"kB page size", "hugetlbfs /dev/hugepages hugetlbfs rw,pagesize=2048k 0 0", 2048},
+		{"default size", "hugetlbfs /dev/hugepages hugetlbfs rw,relatime 0 0", 2048},
+		{"other mount", "hugetlbfs /mnt/huge hugetlbfs rw,pagesize=1G 0 0", 0},
+		{"not hugetlbfs", "tmpfs /dev/hugepages tmpfs rw 0 0", 0},
+		{"not mounted", "proc /proc proc rw 0 0", 0},
+	}
+	for _, tt := range tests {
+		mounts := "sysfs /sys sysfs rw 0 0\n" + tt.mounts + "\n"
+		if got := hugetlbfsPageSizeKB(mounts, meminfo, hugepagesDir); got != tt.want {
+			t.Errorf("%s: page size %dkB, want %dkB", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCheckHugepages(t *testing.T) {
+	tests := []struct {
+		name       string
+		mem        []int
+		pageSizeKB uint64
+		// part of the error, none if empty
+		wantErr string
+	}{
+		{name: "enough 2M pages", mem: []int{515}, pageSizeKB: 2048},
+		{name: "too few 2M pages", mem: []int{1500}, pageSizeKB: 2048, wantErr: "numa node 0 needs 1500 MB of 2048kB huge pages but only 1024 MB are free"},
+		{name: "enough 1G pages", mem: []int{1500}, pageSizeKB: 1 << 20},
+		{name: "largest pool", mem: []int{1500}},
+		{name: "unused node", mem: []int{515, 0}, pageSizeKB: 2048},
+		{name: "second node", mem: []int{515, 515}, pageSizeKB: 2048, wantErr: "numa node 1 needs 515 MB"},
+		{name: "page size missing on the node", mem: []int{0, 100}, pageSizeKB: 1 << 20, wantErr: "only 0 MB are free"},
+		{name: "node missing", mem: []int{0, 0, 515}, wantErr: "failed to read the huge pages of numa node 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := newFakeSysfs()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.remove()
+			for _, n := range []struct{ node, sizeKB, pages int }{{0, 2048, 512}, {0, 1 << 20, 2}, {1, 2048, 100}} {
+				if err := f.addNumaNode(n.node, n.sizeKB, n.pages); err != nil {
+					t.Fatal(err)
+				}
+			}
+			err = checkHugepages(f.root, tt.mem, tt.pageSizeKB)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatal(err)
+			case tt.wantErr != "" && err == nil:
+				t.Fatalf("no error, want %q", tt.wantErr)
+			case tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr):
+				t.Fatalf("error %q, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	// without numa nodes in sysfs there is nothing to check
+	f, err := newFakeSysfs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.remove()
+	if err := checkHugepages(f.root, []int{515}, 2048); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	iommuGroupsDir = "kernel/iommu_groups"
+	// vfio module parameter, only there once vfio is loaded
+	vfioNoIommuParam = "module/vfio/parameters/enable_unsafe_noiommu_mode"
+	vfioDriver       = "vfio-pci"
+)
+
+// drivers a device may be on while another device of its iommu group is used through vfio
+var vfioGroupDrivers = []string{vfioDriver, "pci-stub", "pcieport"}
+
+// iommuGroup returns the iommu group of a device, empty if it has none
+func (b *pciBus) iommuGroup(pci string) string {
+	target, err := os.Readlink(filepath.Join(b.deviceDir(pci), "iommu_group"))
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(target)
+}
+
+// iommuGroupMembers returns the devices of an iommu group
+func (b *pciBus) iommuGroupMembers(group string) ([]string, error) {
+	entries, err := ioutil.ReadDir(filepath.Join(b.root, iommuGroupsDir, group, "devices"))
+	if err != nil {
+		return nil, err
+	}
+	var members []string
+	for _, e := range entries {
+		members = append(members, e.Name())
+	}
+	sort.Strings(members)
+	return members, nil
+}
+
+// checkIommu makes sure every port going to vfio-pci can be used: its iommu group must only hold
+// ports of this run going to vfio-pci, and devices that are unbound or on a vfio friendly driver.
+// Without iommu the ports need the unsafe no-iommu mode of vfio, which is only turned on if
+// the bus allows it.
+func (b *pciBus) checkIommu(pci pciArray, drivers map[string]string) error {
+	checked := make(map[string]bool)
+	for _, p := range pci {
+		if drivers[p] != vfioDriver {
+			continue
+		}
+		group := b.iommuGroup(p)
+		if group == "" {
+			if !b.vfioNoIommu {
+				return fmt.Errorf("%s has no iommu group, enable the iommu with intel_iommu=on iommu=pt "+
+					"(amd_iommu=on on AMD), or use -vfio-noiommu in a VM without vIOMMU", p)
+			}
+			if err := b.enableNoIommu(); err != nil {
+				return err
+			}
+			continue
+		}
+		if checked[group] {
+			continue
+		}
+		checked[group] = true
+		members, err := b.iommuGroupMembers(group)
+		if err != nil {
+			return fmt.Errorf("failed to read iommu group %s of %s: %v", group, p, err)
+		}
+		var desc, blocking []string
+		for _, m := range members {
+			driver := b.boundDriver(m)
+			desc = append(desc, fmt.Sprintf("%s (%s)", m, driverName(driver)))
+			if _, ok := drivers[m]; ok {
+				if drivers[m] != vfioDriver {
+					blocking = append(blocking, fmt.Sprintf("%s goes to %s", m, drivers[m]))
+				}
+				continue
+			}
+			if driver != "" && !containsString(vfioGroupDrivers, driver) {
+				blocking = append(blocking, fmt.Sprintf("%s is on %s", m, driver))
+			}
+		}
+		log.Printf("iommu group %s of %s: %s", group, p, strings.Join(desc, ", "))
+		if len(blocking) > 0 {
+			return fmt.Errorf("iommu group %s of %s is not viable for %s, %s; pass these devices with -pci too, "+
+				"bind them to %s or pci-stub, or unbind them", group, p, vfioDriver, strings.Join(blocking, ", "),
+				vfioDriver)
+		}
+	}
+	return nil
+}
+
+// enableNoIommu turns on the unsafe no-iommu mode of vfio, vfio must be loaded
+func (b *pciBus) enableNoIommu() error {
+	path := filepath.Join(b.root, vfioNoIommuParam)
+	out, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("vfio has no no-iommu mode, is it built with CONFIG_VFIO_NOIOMMU? %v", err)
+	}
+	if v := strings.TrimSpace(string(out)); v == "Y" || v == "1" {
+		return nil
+	}
+	log.Printf("WARNING: enabling the unsafe no-iommu mode of vfio, DMA of the ports is not isolated")
+	log.Printf("enableNoIommu: echo 1 > %s", path)
+	return b.writer.writeFile(path, []byte("1"))
+}
+
+func driverName(driver string) string {
+	if driver == "" {
+		return "no driver"
+	}
+	return driver
+}
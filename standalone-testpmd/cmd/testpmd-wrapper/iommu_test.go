@@ -0,0 +1,132 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckIommu(t *testing.T) {
+	const (
+		port0  = "0000:86:00.0"
+		port1  = "0000:86:00.1"
+		other  = "0000:86:00.2"
+		bridge = "0000:85:00.0"
+	)
+	tests := []struct {
+		name string
+		// iommu group members, no group for the ports left out
+		group []string
+		// other holds this driver, none if empty
+		otherDriver string
+		drivers     map[string]string
+		noIommu     bool
+		vfioLoaded  bool
+		// part of the error, none if empty
+		wantErr string
+		// the no-iommu mode of vfio is turned on
+		wantNoIommu bool
+	}{
+		{
+			name:    "viable group",
+			group:   []string{port0, port1, other, bridge},
+			drivers: map[string]string{port0: vfioDriver, port1: vfioDriver},
+		},
+		{
+			name:        "device on vfio friendly driver",
+			group:       []string{port0, other},
+			otherDriver: "pci-stub",
+			drivers:     map[string]string{port0: vfioDriver},
+		},
+		{
+			name:        "device on host driver",
+			group:       []string{port0, port1, other},
+			otherDriver: "i40e",
+			drivers:     map[string]string{port0: vfioDriver, port1: vfioDriver},
+			wantErr:     "0000:86:00.2 is on i40e",
+		},
+		{
+			name:    "port going to another driver",
+			group:   []string{port0, port1},
+			drivers: map[string]string{port0: vfioDriver, port1: "mlx5_core"},
+			wantErr: "0000:86:00.1 goes to mlx5_core",
+		},
+		{
+			name:    "port not on vfio",
+			group:   []string{port0, other},
+			drivers: map[string]string{port0: "mlx5_core"},
+		},
+		{
+			name:    "no group",
+			drivers: map[string]string{port0: vfioDriver},
+			wantErr: "has no iommu group",
+		},
+		{
+			name:        "no group with noiommu",
+			drivers:     map[string]string{port0: vfioDriver},
+			noIommu:     true,
+			vfioLoaded:  true,
+			wantNoIommu: true,
+		},
+		{
+			name:    "no group with noiommu without vfio",
+			drivers: map[string]string{port0: vfioDriver},
+			noIommu: true,
+			wantErr: "no no-iommu mode",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := newFakeSysfs()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.remove()
+			for _, d := range []string{"i40e", "pci-stub", "pcieport"} {
+				if err := f.addDriver(d, d == "i40e"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			devices := []struct{ pci, driver string }{
+				{port0, "i40e"}, {port1, "i40e"}, {other, tt.otherDriver}, {bridge, "pcieport"},
+			}
+			for _, d := range devices {
+				if err := f.addDevice(d.pci, "0x8086", "0x1572", 0, d.driver, ""); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if len(tt.group) > 0 {
+				if err := f.addIommuGroup(simIommuGroup, tt.group...); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.vfioLoaded {
+				if err := f.load(vfioDriver); err != nil {
+					t.Fatal(err)
+				}
+			}
+			b := f.bus()
+			b.vfioNoIommu = tt.noIommu
+			var pci pciArray
+			for _, p := range []string{port0, port1} {
+				if _, ok := tt.drivers[p]; ok {
+					pci = append(pci, p)
+				}
+			}
+			err = b.checkIommu(pci, tt.drivers)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatal(err)
+			case tt.wantErr != "" && err == nil:
+				t.Fatalf("no error, want %q", tt.wantErr)
+			case tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr):
+				t.Fatalf("error %q, want %q", err, tt.wantErr)
+			}
+			param, _ := ioutil.ReadFile(filepath.Join(f.root, vfioNoIommuParam))
+			if on := strings.TrimSpace(string(param)) == "1"; on != tt.wantNoIommu {
+				t.Errorf("no-iommu mode is %v, want %v", on, tt.wantNoIommu)
+			}
+		})
+	}
+}
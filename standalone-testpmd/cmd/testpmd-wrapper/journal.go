@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+const defaultJournalPath = "/var/lib/testpmd-wrapper/bindings.json"
+
+// journalEntry is the persisted form of pciInfo
+type journalEntry struct {
+	Pci           string `json:"pci"`
+	DriverPre     string `json:"driverPre"`
+	DriverCur     string `json:"driverCur"`
+	WasKernelPort bool   `json:"wasKernelPort"`
+	Kmod          string `json:"kmod"`
+	Vendor        string `json:"vendor"`
+	Device        string `json:"device"`
+	DpdkUseKmod   bool   `json:"dpdkUseKmod"`
+	// the kernel net device, to restore it with the port
+	Netdev *netdevState `json:"netdev,omitempty"`
+}
+
+// journalSriov is the persisted form of sriovState
+type journalSriov struct {
+	Pf     string `json:"pf"`
+	NumVfs int    `json:"numVfs"`
+	// the settings of the VFs the PF already had
+	Vfs []vfSettings `json:"vfs,omitempty"`
+}
+
+type journalFile struct {
+	// the sysfs the entries were recorded against
+	SysfsRoot string         `json:"sysfsRoot"`
+	Ports     []journalEntry `json:"ports"`
+	// the VF count and settings the PF had before the wrapper set up its VFs
+	Sriov *journalSriov `json:"sriov,omitempty"`
+}
+
+// bindingJournal persists the port records, so that ports left on the dpdk driver by a
+// wrapper that was killed can be restored by the next one
+type bindingJournal struct {
+	path string
+}
+
+// save atomically replaces the journal with the records of the given ports and the VF count
+// and settings to restore, if any
+func (j *bindingJournal) save(root string, pci pciArray, record map[string]*pciInfo, sriov *sriovState) error {
+	f := journalFile{SysfsRoot: root}
+	if sriov != nil {
+		f.Sriov = &journalSriov{Pf: sriov.pf, NumVfs: sriov.numVfs, Vfs: sriov.vfs}
+	}
+	for _, p := range pci {
+		info, ok := record[p]
+		if !ok {
+			continue
+		}
+		f.Ports = append(f.Ports, journalEntry{
+			Pci:           p,
+			DriverPre:     info.driverPre,
+			DriverCur:     info.driverCur,
+			WasKernelPort: info.wasKernelPort,
+			Kmod:          info.kmod,
+			Vendor:        info.vendor,
+			Device:        info.device,
+			DpdkUseKmod:   info.dpdkUseKmod,
+			Netdev:        info.netdev,
+		})
+	}
+	data, err := json.MarshalIndent(&f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0755); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(j.path), filepath.Base(j.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), j.path)
+}
+
+// load returns the journaled ports and VFs, no ports and a nil VF count if there is
+// no journal
+func (j *bindingJournal) load() (string, pciArray, map[string]*pciInfo, *sriovState, error) {
+	record := make(map[string]*pciInfo)
+	data, err := ioutil.ReadFile(j.path)
+	if os.IsNotExist(err) {
+		return "", nil, record, nil, nil
+	} else if err != nil {
+		return "", nil, nil, nil, err
+	}
+	var f journalFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return "", nil, nil, nil, fmt.Errorf("corrupted journal %s: %v", j.path, err)
+	}
+	var pci pciArray
+	for _, e := range f.Ports {
+		pci = append(pci, e.Pci)
+		record[e.Pci] = &pciInfo{
+			driverPre:     e.DriverPre,
+			driverCur:     e.DriverCur,
+			wasKernelPort: e.WasKernelPort,
+			kmod:          e.Kmod,
+			vendor:        e.Vendor,
+			device:        e.Device,
+			dpdkUseKmod:   e.DpdkUseKmod,
+			netdev:        e.Netdev,
+		}
+	}
+	var sriov *sriovState
+	if f.Sriov != nil {
+		sriov = &sriovState{pf: f.Sriov.Pf, numVfs: f.Sriov.NumVfs, vfs: f.Sriov.Vfs}
+	}
+	return f.SysfsRoot, pci, record, sriov, nil
+}
+
+func (j *bindingJournal) remove() error {
+	if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// restoreFromJournal puts the ports of a stale journal back on the driver they had
+// before the wrapper that wrote it took them over, then the PF back to its VFs
+func (b *pciBus) restoreFromJournal() error {
+	if b.journal == nil {
+		return nil
+	}
+	root, pci, record, sriov, err := b.journal.load()
+	if err != nil {
+		return err
+	}
+	if len(pci) == 0 && sriov == nil {
+		return nil
+	}
+	if root != b.root {
+		return fmt.Errorf("journal %s was written for sysfs at %s, not %s", b.journal.path, root, b.root)
+	}
+	log.Printf("restoring ports from stale journal %s: %+q\n", b.journal.path, pci)
+	if err := b.rollbackPorts(pci, record); err != nil {
+		return err
+	}
+	if sriov != nil {
+		b.sriov = sriov
+		return b.restoreSriov(sriov)
+	}
+	return b.journal.remove()
+}
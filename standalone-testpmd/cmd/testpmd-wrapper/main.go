@@ -1,81 +1,200 @@
 package main
 
 import (
-	"flag"
+	"context"
 	"fmt"
 	"log"
 	"net"
 	"os"
 	"os/signal"
-	"regexp"
 	"strings"
 	"syscall"
+	"time"
 
 	pb "github.com/redhat-nfvpe/container-perf-tools/standalone-testpmd/rpc"
+	pbv2 "github.com/redhat-nfvpe/container-perf-tools/standalone-testpmd/rpc/v2"
 	"google.golang.org/grpc"
 )
 
-func (p *pciArray) String() string {
-	return strings.Join(*p, " ")
-}
-
-func (p *pciArray) Set(value string) error {
-	// normalize pci address to start with 0000: prefix
-	pci := normalizePci(value)
-	if _, err := os.Stat(pciDeviceDir + pci); os.IsNotExist(err) {
-		log.Fatalf("invalid pci %s", value)
-		return fmt.Errorf("invalid pci %s", value)
-	}
-	*p = append(*p, pci)
-	return nil
-}
-
 func main() {
-	grpcPort := flag.Int("grpc-port", 9000, "grpc port")
-	autoStart := flag.Bool("auto", false, "auto start in io mode")
-	queues := flag.Int("queues", 1, "number of rxq/txq")
-	ring := flag.Int("ring-size", 2048, "ring size")
-	var pci pciArray
-	flag.Var(&pci, "pci", "pci address, can specify multiple times")
-	testpmdPath := flag.String("testpmd-path", "testpmd", "if not in PATH, specify the testpmd location")
-	dpdkDriver := flag.String("dpdk-driver", "vfio-pci", "dpdk driver")
-	flag.Parse()
-	// if pci not specified on CLI, try enviroment vars
-	if len(pci) == 0 {
-		for _, e := range os.Environ() {
-			pair := strings.SplitN(e, "=", 2)
-			if match, _ := regexp.MatchString("PCIDEVICE", pair[0]); match {
-				pci = append(pci, normalizePci(pair[1]))
+	cfg, opts, args, err := loadConfig(os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	if opts.printConfig {
+		if err := cfg.print(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	// "restore" puts the ports of a stale journal back and exits
+	if len(args) > 0 {
+		if args[0] != "restore" || len(args) > 1 {
+			log.Fatalf("unknown command %s, only restore is supported\n", strings.Join(args, " "))
+		}
+		if cfg.Journal == "" {
+			log.Fatalf("restore needs a journal\n")
+		}
+		bus := newHostPciBus(cfg.SysfsRoot)
+		bus.journal = &bindingJournal{path: cfg.Journal}
+		if err := bus.restoreFromJournal(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	pci, vdevs := pciArray(cfg.Pci), vdevArray(cfg.Vdev)
+	sriov, err := cfg.sriov()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if sriov != nil && containsString(pci, sriov.pf) {
+		log.Fatalf("%s is given with both -pf and -pci\n", sriov.pf)
+	}
+	// without any port, exit
+	if len(pci) == 0 && len(vdevs) == 0 && sriov == nil {
+		log.Fatalf("pci address or vdev not provided\n")
+	}
+	qmap, err := cfg.queueMap()
+	if err != nil {
+		log.Fatal(err)
+	}
+	peerMacs, err := cfg.peerMacs()
+	if err != nil {
+		log.Fatal(err)
+	}
+	noisy, err := cfg.noisy()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if cfg.DriverTable != "" {
+		if err := loadDriverTable(cfg.DriverTable); err != nil {
+			log.Fatal(err)
+		}
+	}
+	b, err := newBackend(cfg.Backend, cfg.SysfsRoot, pci, cfg.Pf)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer b.close()
+	bus := b.bus()
+	bus.vfioNoIommu = cfg.VfioNoIommu
+	// the sim ports live in a temporary sysfs, there is nothing to restore after a crash
+	if cfg.Backend == "host" && cfg.Journal != "" {
+		bus.journal = &bindingJournal{path: cfg.Journal}
+		if err := bus.restoreFromJournal(); err != nil {
+			log.Fatalf("failed to restore the ports of a previous run, fix or remove %s: %v", cfg.Journal, err)
+		}
+	}
+	for _, p := range pci {
+		if _, err := os.Stat(bus.deviceDir(p)); os.IsNotExist(err) {
+			log.Fatalf("invalid pci %s", p)
+		}
+	}
+	// the VFs are ports like the others, after those of -pci
+	var vfState *sriovState
+	if sriov != nil {
+		vfs, state, err := bus.setupSriov(sriov)
+		if err != nil {
+			log.Fatal(err)
+		}
+		pci = append(pci, vfs...)
+		vfState = state
+	}
+	// vdev ports need no binding
+	pciRecord := make(map[string]*pciInfo)
+	portsBound := false
+	// cleanup puts the ports and the VF count back and frees the huge pages of testpmd, a step
+	// that fails doesn't skip the next ones. It returns false if any of them failed.
+	cleanup := func() bool {
+		ok := true
+		if portsBound {
+			if err := bus.restoreKernalPorts(pci, pciRecord); err != nil {
+				log.Printf("failed to restore the ports: %v", err)
+				ok = false
+			}
+		}
+		if vfState != nil {
+			if err := bus.restoreSriov(vfState); err != nil {
+				log.Printf("failed to restore the VFs of %s: %v", vfState.pf, err)
+				ok = false
+			}
+		}
+		// the file prefix is only set once testpmd is started
+		if pTestpmd != nil && pTestpmd.filePrefix != "" {
+			if err := pTestpmd.releaseHugePages(); err != nil {
+				log.Printf("failed to release the huge pages: %v", err)
+				ok = false
 			}
 		}
+		return ok
 	}
-	// if still have no pci info, then exit
-	if len(pci) == 0 {
-		log.Fatalf("pci address not provided\n")
+	fatal := func(err error) {
+		log.Printf("%v", err)
+		cleanup()
+		b.close()
+		os.Exit(1)
 	}
 
-	pciRecord := make(map[string]*pciInfo)
-	if err := setupDpdkPorts(*dpdkDriver, pci, pciRecord); err != nil {
-		log.Fatal(err)
+	pTestpmd = &testpmd{b: b, noisy: noisy}
+	// fail before touching the ports if there is no testpmd or it can't get its cores or memory
+	if err := pTestpmd.detectDpdk(cfg.TestpmdPath, cfg.DpdkVersion); err != nil {
+		fatal(err)
+	}
+	if err := pTestpmd.planCores(pci, vdevs, cfg.Queues, cfg.CorePolicy, qmap); err != nil {
+		fatal(err)
+	}
+	if err := pTestpmd.planMemory(pci, vdevs, cfg.Queues, cfg.RingSize, cfg.MbufSize); err != nil {
+		fatal(err)
 	}
 
-	pTestpmd = &testpmd{}
-	if err := pTestpmd.init(pci, *queues, *ring, *testpmdPath); err != nil {
-		log.Fatalf("%v", err)
+	if len(pci) > 0 {
+		// the ports are rolled back if this fails
+		if err := bus.setupDpdkPorts(cfg.DpdkDriver, cfg.DriverOverrides, pci, pciRecord); err != nil {
+			fatal(err)
+		}
+		portsBound = true
+	}
+
+	if err := pTestpmd.init(pci, vdevs, cfg.EalArgs, cfg.Queues, cfg.RingSize, cfg.MbufSize, cfg.TestpmdPath); err != nil {
+		if pTestpmd.x != nil {
+			pTestpmd.stop()
+		}
+		fatal(err)
 	}
-	if *autoStart {
-		log.Printf("auto start io mode\n")
-		if err := pTestpmd.ioMode(); err != nil {
+	for _, p := range peerMacs {
+		if err := pTestpmd.setPeerMac(context.Background(), p.PortNum, p.MacAddress); err != nil {
 			log.Fatal(err)
 		}
 	}
+	fwdMode := cfg.FwdMode
+	if fwdMode == "" && cfg.Auto {
+		fwdMode = "io"
+	}
+	if fwdMode != "" {
+		log.Printf("auto start %s mode\n", fwdMode)
+		if err := pTestpmd.setFwdMode(context.Background(), fwdMode); err != nil {
+			pTestpmd.stop()
+			fatal(err)
+		}
+	}
 
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", *grpcPort))
+	if cfg.MetricsPort != 0 {
+		go func() {
+			addr := fmt.Sprintf("%s:%d", cfg.ListenAddress, cfg.MetricsPort)
+			if err := serveMetrics(addr, pTestpmd, time.Duration(cfg.MetricsCache)); err != nil {
+				log.Printf("metrics server stopped: %v", err)
+			}
+		}()
+	}
+
+	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", cfg.ListenAddress, cfg.GrpcPort))
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		pTestpmd.stop()
+		fatal(fmt.Errorf("failed to listen: %v", err))
 	}
 	s := grpc.NewServer()
-	pb.RegisterTestpmdServer(s, &server{})
+	pb.RegisterTestpmdServer(s, &server{t: pTestpmd})
+	pbv2.RegisterTestpmdServer(s, &serverV2{t: pTestpmd, bindings: pciRecord})
 
 	done := make(chan int)
 	go func() error {
@@ -93,8 +212,8 @@ func main() {
 	// make sure grpc thread is done
 	<-done
 	pTestpmd.stop()
-	if err := restoreKernalPorts(pci, pciRecord); err != nil {
-		log.Fatal(err)
+	if !cleanup() {
+		b.close()
+		os.Exit(1)
 	}
-	pTestpmd.releaseHugePages()
 }
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	pb "github.com/redhat-nfvpe/container-perf-tools/standalone-testpmd/rpc"
+)
+
+var (
+	portLabels = []string{"port"}
+
+	rxPacketsDesc   = prometheus.NewDesc("testpmd_port_rx_packets_total", "Packets received by the port.", portLabels, nil)
+	txPacketsDesc   = prometheus.NewDesc("testpmd_port_tx_packets_total", "Packets sent by the port.", portLabels, nil)
+	rxBytesDesc     = prometheus.NewDesc("testpmd_port_rx_bytes_total", "Bytes received by the port.", portLabels, nil)
+	txBytesDesc     = prometheus.NewDesc("testpmd_port_tx_bytes_total", "Bytes sent by the port.", portLabels, nil)
+	rxMissedDesc    = prometheus.NewDesc("testpmd_port_rx_missed_total", "Packets dropped by the port hardware.", portLabels, nil)
+	rxErrorsDesc    = prometheus.NewDesc("testpmd_port_rx_errors_total", "Erroneous packets received by the port.", portLabels, nil)
+	rxNombufDesc    = prometheus.NewDesc("testpmd_port_rx_nombuf_total", "Rx mbuf allocation failures.", portLabels, nil)
+	txErrorsDesc    = prometheus.NewDesc("testpmd_port_tx_errors_total", "Failed transmitted packets.", portLabels, nil)
+	fwdRxDropDesc   = prometheus.NewDesc("testpmd_fwd_rx_dropped_total", "Packets dropped on rx by the forwarding engine.", portLabels, nil)
+	fwdTxDropDesc   = prometheus.NewDesc("testpmd_fwd_tx_dropped_total", "Packets dropped on tx by the forwarding engine.", portLabels, nil)
+	fwdModeDesc     = prometheus.NewDesc("testpmd_forwarding_mode", "Current forwarding mode, always 1.", []string{"mode"}, nil)
+	fwdRunningDesc  = prometheus.NewDesc("testpmd_forwarding_running", "1 if packet forwarding is started.", nil, nil)
+	uptimeDesc      = prometheus.NewDesc("testpmd_uptime_seconds", "Seconds since testpmd was started.", nil, nil)
+	scrapeErrorDesc = prometheus.NewDesc("testpmd_scrape_error", "1 if the counters could not be read from testpmd.", nil, nil)
+)
+
+// metricsSnapshot is what one round trip to the testpmd session gives us
+type metricsSnapshot struct {
+	ports    []*portCounters
+	fwdStats *pb.FwdStats
+	err      error
+	taken    time.Time
+}
+
+// testpmdCollector serves scrapes from a cached snapshot so that frequent scrapes
+// don't keep the interactive session busy
+type testpmdCollector struct {
+	t   testpmdOps
+	ttl time.Duration
+
+	mu       sync.Mutex
+	snapshot *metricsSnapshot
+}
+
+func newTestpmdCollector(t testpmdOps, ttl time.Duration) *testpmdCollector {
+	return &testpmdCollector{t: t, ttl: ttl}
+}
+
+func (c *testpmdCollector) getSnapshot() *metricsSnapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.snapshot != nil && time.Since(c.snapshot.taken) < c.ttl {
+		return c.snapshot
+	}
+	snapshot := &metricsSnapshot{taken: time.Now()}
+	snapshot.ports, snapshot.err = c.t.getPortCounters(context.Background())
+	if snapshot.err == nil {
+		snapshot.fwdStats, snapshot.err = c.t.getFwdStats(context.Background())
+	}
+	if snapshot.err != nil {
+		log.Printf("metrics: failed to read counters: %v\n", snapshot.err)
+	}
+	c.snapshot = snapshot
+	return snapshot
+}
+
+func (c *testpmdCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{rxPacketsDesc, txPacketsDesc, rxBytesDesc, txBytesDesc, rxMissedDesc,
+		rxErrorsDesc, rxNombufDesc, txErrorsDesc, fwdRxDropDesc, fwdTxDropDesc, fwdModeDesc, fwdRunningDesc,
+		uptimeDesc, scrapeErrorDesc} {
+		ch <- d
+	}
+}
+
+func (c *testpmdCollector) Collect(ch chan<- prometheus.Metric) {
+	snapshot := c.getSnapshot()
+	counter := func(d *prometheus.Desc, v uint64, port int32) {
+		ch <- prometheus.MustNewConstMetric(d, prometheus.CounterValue, float64(v), strconv.Itoa(int(port)))
+	}
+	for _, p := range snapshot.ports {
+		counter(rxPacketsDesc, p.rxPackets, p.portNum)
+		counter(txPacketsDesc, p.txPackets, p.portNum)
+		counter(rxBytesDesc, p.rxBytes, p.portNum)
+		counter(txBytesDesc, p.txBytes, p.portNum)
+		counter(rxMissedDesc, p.rxMissed, p.portNum)
+		counter(rxErrorsDesc, p.rxErrors, p.portNum)
+		counter(rxNombufDesc, p.rxNombuf, p.portNum)
+		counter(txErrorsDesc, p.txErrors, p.portNum)
+	}
+	if snapshot.fwdStats != nil {
+		for _, p := range snapshot.fwdStats.PortStats {
+			counter(fwdRxDropDesc, p.RxDropped, p.PortNum)
+			counter(fwdTxDropDesc, p.TxDropped, p.PortNum)
+		}
+	}
+	scrapeError := 0.0
+	if snapshot.err != nil {
+		scrapeError = 1
+	}
+	ch <- prometheus.MustNewConstMetric(scrapeErrorDesc, prometheus.GaugeValue, scrapeError)
+
+	mode, running, started := c.t.getState()
+	if mode != "" {
+		ch <- prometheus.MustNewConstMetric(fwdModeDesc, prometheus.GaugeValue, 1, mode)
+	}
+	runningValue := 0.0
+	if running {
+		runningValue = 1
+	}
+	ch <- prometheus.MustNewConstMetric(fwdRunningDesc, prometheus.GaugeValue, runningValue)
+	ch <- prometheus.MustNewConstMetric(uptimeDesc, prometheus.GaugeValue, time.Since(started).Seconds())
+}
+
+// serveMetrics blocks serving /metrics on the given port
+func serveMetrics(addr string, t testpmdOps, ttl time.Duration) error {
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(newTestpmdCollector(t, ttl)); err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	log.Printf("serving metrics on %s/metrics\n", addr)
+	return http.ListenAndServe(addr, mux)
+}
@@ -0,0 +1,405 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// netdevState is the kernel net device of a port before dpdk takes the port over,
+// it is journaled with the port
+type netdevState struct {
+	Name     string   `json:"name"`
+	Mtu      int      `json:"mtu"`
+	Up       bool     `json:"up"`
+	Addrs    []string `json:"addrs,omitempty"`
+	AltNames []string `json:"altNames,omitempty"`
+	// ethtool -g and -l, 0 when the driver doesn't report it
+	RxRing     int `json:"rxRing,omitempty"`
+	TxRing     int `json:"txRing,omitempty"`
+	RxChannels int `json:"rxChannels,omitempty"`
+	TxChannels int `json:"txChannels,omitempty"`
+	Combined   int `json:"combined,omitempty"`
+	// the routes through the device and the VLAN links on top of it
+	Routes []routeState `json:"routes,omitempty"`
+	Vlans  []vlanState  `json:"vlans,omitempty"`
+}
+
+// routeState is a route through a net device, without those the kernel adds for the addresses
+type routeState struct {
+	// empty for the default route
+	Dst      string `json:"dst,omitempty"`
+	Gw       string `json:"gw,omitempty"`
+	Src      string `json:"src,omitempty"`
+	Priority int    `json:"priority,omitempty"`
+	Table    int    `json:"table,omitempty"`
+	Scope    int    `json:"scope,omitempty"`
+	Protocol int    `json:"protocol,omitempty"`
+}
+
+func (r routeState) String() string {
+	s := r.Dst
+	if s == "" {
+		s = "default"
+	}
+	if r.Gw != "" {
+		s += " via " + r.Gw
+	}
+	if r.Table != 0 && r.Table != unix.RT_TABLE_MAIN {
+		s += fmt.Sprintf(" table %d", r.Table)
+	}
+	return s
+}
+
+// vlanState is a VLAN link on top of a net device, it goes away with the device
+type vlanState struct {
+	Name   string       `json:"name"`
+	VlanId int          `json:"vlanId"`
+	Proto  int          `json:"proto,omitempty"`
+	Mtu    int          `json:"mtu"`
+	Up     bool         `json:"up"`
+	Addrs  []string     `json:"addrs,omitempty"`
+	Routes []routeState `json:"routes,omitempty"`
+}
+
+// netdevManager reads and re-applies the state of a net device
+type netdevManager interface {
+	snapshot(name string) (*netdevState, error)
+	// restore applies state to the net device called name, it returns what it couldn't restore
+	restore(name string, state *netdevState) []string
+}
+
+// hostNetdevs uses netlink, ip for the altnames and ethtool
+type hostNetdevs struct{}
+
+func (hostNetdevs) snapshot(name string) (*netdevState, error) {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return nil, err
+	}
+	attrs := link.Attrs()
+	s := &netdevState{Name: attrs.Name, Mtu: attrs.MTU, Up: attrs.Flags&net.FlagUp != 0}
+	if s.Addrs, err = linkAddrs(link); err != nil {
+		return nil, err
+	}
+	if s.Routes, err = linkRoutes(link); err != nil {
+		return nil, err
+	}
+	links, err := netlink.LinkList()
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range links {
+		vlan, ok := l.(*netlink.Vlan)
+		if !ok || vlan.ParentIndex != attrs.Index {
+			continue
+		}
+		v := vlanState{
+			Name:   vlan.Name,
+			VlanId: vlan.VlanId,
+			Proto:  int(vlan.VlanProtocol),
+			Mtu:    vlan.MTU,
+			Up:     vlan.Flags&net.FlagUp != 0,
+		}
+		if v.Addrs, err = linkAddrs(vlan); err != nil {
+			return nil, err
+		}
+		if v.Routes, err = linkRoutes(vlan); err != nil {
+			return nil, err
+		}
+		s.Vlans = append(s.Vlans, v)
+	}
+	s.AltNames = ipAltNames(name)
+	if ring, err := ethtoolCurrent("-g", name); err == nil {
+		s.RxRing, s.TxRing = ring["RX"], ring["TX"]
+	}
+	if channels, err := ethtoolCurrent("-l", name); err == nil {
+		s.RxChannels, s.TxChannels, s.Combined = channels["RX"], channels["TX"], channels["Combined"]
+	}
+	return s, nil
+}
+
+func (hostNetdevs) restore(name string, s *netdevState) []string {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return []string{fmt.Sprintf("everything, no net device %s: %v", name, err)}
+	}
+	var failed []string
+	if name != s.Name {
+		netlink.LinkSetDown(link)
+		if err := netlink.LinkSetName(link, s.Name); err != nil {
+			failed = append(failed, fmt.Sprintf("name %s: %v", s.Name, err))
+		} else {
+			name = s.Name
+		}
+	}
+	current := ipAltNames(name)
+	for _, alt := range s.AltNames {
+		if containsString(current, alt) {
+			continue
+		}
+		if out, err := exec.Command("ip", "link", "property", "add", "dev", name, "altname", alt).CombinedOutput(); err != nil {
+			failed = append(failed, fmt.Sprintf("altname %s: %s", alt, strings.TrimSpace(string(out))))
+		}
+	}
+	if link.Attrs().MTU != s.Mtu {
+		if err := netlink.LinkSetMTU(link, s.Mtu); err != nil {
+			failed = append(failed, fmt.Sprintf("mtu %d: %v", s.Mtu, err))
+		}
+	}
+	if args := ethtoolArgs(map[string]int{"rx": s.RxRing, "tx": s.TxRing}); len(args) > 0 {
+		if out, err := exec.Command("ethtool", append([]string{"-G", name}, args...)...).CombinedOutput(); err != nil {
+			failed = append(failed, fmt.Sprintf("ring %s: %s", strings.Join(args, " "), strings.TrimSpace(string(out))))
+		}
+	}
+	if args := ethtoolArgs(map[string]int{"rx": s.RxChannels, "tx": s.TxChannels, "combined": s.Combined}); len(args) > 0 {
+		if out, err := exec.Command("ethtool", append([]string{"-L", name}, args...)...).CombinedOutput(); err != nil {
+			failed = append(failed, fmt.Sprintf("channels %s: %s", strings.Join(args, " "), strings.TrimSpace(string(out))))
+		}
+	}
+	failed = append(failed, restoreAddrs(link, s.Addrs)...)
+	if s.Up {
+		if err := netlink.LinkSetUp(link); err != nil {
+			failed = append(failed, fmt.Sprintf("link up: %v", err))
+		}
+	}
+	// a gateway has to be reachable through the addresses of the link first
+	failed = append(failed, restoreRoutes(link, s.Routes)...)
+	for _, v := range s.Vlans {
+		for _, f := range restoreVlan(link, v) {
+			failed = append(failed, fmt.Sprintf("vlan %s: %s", v.Name, f))
+		}
+	}
+	return failed
+}
+
+// linkAddrs returns the addresses of a link
+func linkAddrs(link netlink.Link) ([]string, error) {
+	addrs, err := netlink.AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, a := range addrs {
+		// the kernel adds the link local address by itself
+		if a.IP.IsLinkLocalUnicast() {
+			continue
+		}
+		out = append(out, a.IPNet.String())
+	}
+	return out, nil
+}
+
+// linkRoutes returns the routes through a link in all tables
+func linkRoutes(link netlink.Link) ([]routeState, error) {
+	filter := &netlink.Route{LinkIndex: link.Attrs().Index, Table: unix.RT_TABLE_UNSPEC}
+	routes, err := netlink.RouteListFiltered(netlink.FAMILY_ALL, filter, netlink.RT_FILTER_OIF|netlink.RT_FILTER_TABLE)
+	if err != nil {
+		return nil, err
+	}
+	var out []routeState
+	for _, r := range routes {
+		// the kernel adds the routes of the addresses and router advertisements by itself
+		if r.Protocol == unix.RTPROT_KERNEL || r.Protocol == unix.RTPROT_RA {
+			continue
+		}
+		rs := routeState{Priority: r.Priority, Table: r.Table, Scope: int(r.Scope), Protocol: r.Protocol}
+		if r.Dst != nil {
+			rs.Dst = r.Dst.String()
+		}
+		if r.Gw != nil {
+			rs.Gw = r.Gw.String()
+		}
+		if r.Src != nil {
+			rs.Src = r.Src.String()
+		}
+		out = append(out, rs)
+	}
+	return out, nil
+}
+
+// restoreAddrs adds the addresses to a link, it returns those it couldn't add
+func restoreAddrs(link netlink.Link, addrs []string) []string {
+	var failed []string
+	for _, a := range addrs {
+		addr, err := netlink.ParseAddr(a)
+		if err == nil {
+			err = netlink.AddrAdd(link, addr)
+		}
+		if err != nil && !strings.Contains(err.Error(), "exists") {
+			failed = append(failed, fmt.Sprintf("address %s: %v", a, err))
+		}
+	}
+	return failed
+}
+
+// restoreRoutes adds the routes through a link, it returns those it couldn't add
+func restoreRoutes(link netlink.Link, routes []routeState) []string {
+	var failed []string
+	for _, r := range routes {
+		route := &netlink.Route{
+			LinkIndex: link.Attrs().Index,
+			Priority:  r.Priority,
+			Table:     r.Table,
+			Scope:     netlink.Scope(r.Scope),
+			Protocol:  r.Protocol,
+			Gw:        net.ParseIP(r.Gw),
+			Src:       net.ParseIP(r.Src),
+		}
+		var err error
+		if r.Dst != "" {
+			_, route.Dst, err = net.ParseCIDR(r.Dst)
+		}
+		if err == nil {
+			err = netlink.RouteAdd(route)
+		}
+		if err != nil && !strings.Contains(err.Error(), "exists") {
+			failed = append(failed, fmt.Sprintf("route %s: %v", r, err))
+		}
+	}
+	return failed
+}
+
+// restoreVlan creates a VLAN link on top of parent again, it returns what it couldn't restore
+func restoreVlan(parent netlink.Link, v vlanState) []string {
+	link, err := netlink.LinkByName(v.Name)
+	if err != nil {
+		vlan := &netlink.Vlan{
+			LinkAttrs:    netlink.LinkAttrs{Name: v.Name, ParentIndex: parent.Attrs().Index, MTU: v.Mtu},
+			VlanId:       v.VlanId,
+			VlanProtocol: netlink.VlanProtocol(v.Proto),
+		}
+		if err := netlink.LinkAdd(vlan); err != nil {
+			return []string{fmt.Sprintf("everything, failed to create it: %v", err)}
+		}
+		if link, err = netlink.LinkByName(v.Name); err != nil {
+			return []string{fmt.Sprintf("everything: %v", err)}
+		}
+	}
+	failed := restoreAddrs(link, v.Addrs)
+	if v.Up {
+		if err := netlink.LinkSetUp(link); err != nil {
+			failed = append(failed, fmt.Sprintf("link up: %v", err))
+		}
+	}
+	return append(failed, restoreRoutes(link, v.Routes)...)
+}
+
+// ipAltNames returns the altnames of a net device, netlink v1.1 doesn't know them
+func ipAltNames(name string) []string {
+	out, err := exec.Command("ip", "-j", "link", "show", "dev", name).Output()
+	if err != nil {
+		return nil
+	}
+	var links []struct {
+		AltNames []string `json:"altnames"`
+	}
+	if err := json.Unmarshal(out, &links); err != nil || len(links) == 0 {
+		return nil
+	}
+	return links[0].AltNames
+}
+
+// ethtoolCurrent returns the "Current hardware settings" of ethtool -g or -l, like RX: 512
+func ethtoolCurrent(option string, name string) (map[string]int, error) {
+	out, err := exec.Command("ethtool", option, name).Output()
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]int)
+	current := false
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(line, "Current hardware settings") {
+			current = true
+			continue
+		}
+		kv := strings.SplitN(line, ":", 2)
+		if !current || len(kv) != 2 {
+			continue
+		}
+		if v, err := strconv.Atoi(strings.TrimSpace(kv[1])); err == nil {
+			values[strings.TrimSpace(kv[0])] = v
+		}
+	}
+	return values, nil
+}
+
+// ethtoolArgs turns the non zero settings into ethtool arguments
+func ethtoolArgs(settings map[string]int) []string {
+	var args []string
+	for _, k := range []string{"rx", "tx", "combined"} {
+		if v := settings[k]; v > 0 {
+			args = append(args, k, strconv.Itoa(v))
+		}
+	}
+	return args
+}
+
+// snapshotNetdev records the net device of a kernel port, a port without one gets nil
+func (b *pciBus) snapshotNetdev(pci string) *netdevState {
+	name, err := b.netDevice(pci)
+	if err != nil {
+		return nil
+	}
+	s, err := b.netdevs.snapshot(name)
+	if err != nil {
+		log.Printf("failed to record net device %s of %s, it won't be restored: %v", name, pci, err)
+		return nil
+	}
+	var vlans []string
+	for _, v := range s.Vlans {
+		vlans = append(vlans, fmt.Sprintf("%s id %d", v.Name, v.VlanId))
+	}
+	log.Printf("netdev %s of %s: mtu %d, up %v, addresses %q, altnames %q, ring rx %d tx %d, channels rx %d tx %d combined %d, routes %q, vlans %q",
+		s.Name, pci, s.Mtu, s.Up, s.Addrs, s.AltNames, s.RxRing, s.TxRing, s.RxChannels, s.TxChannels, s.Combined, s.Routes, vlans)
+	return s
+}
+
+// restoreNetdev waits for the net device of a port back on its kernel driver and applies the
+// recorded state, what couldn't be restored is logged
+func (b *pciBus) restoreNetdev(pci string, s *netdevState) {
+	if s == nil {
+		return
+	}
+	var name string
+	deadline := time.Now().Add(bindTimeout)
+	for {
+		var err error
+		if name, err = b.netDevice(pci); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			log.Printf("restoreNetdev: %s has no net device after %v, could not restore %s", pci, bindTimeout, s.Name)
+			return
+		}
+		time.Sleep(bindPollInterval)
+	}
+	failed := b.netdevs.restore(name, s)
+	for _, f := range failed {
+		log.Printf("restoreNetdev: %s of %s, could not restore %s", s.Name, pci, f)
+	}
+	if len(failed) == 0 {
+		log.Printf("restoreNetdev: %s of %s restored", s.Name, pci)
+	}
+}
+
+// netDevice returns the name of the net device of a kernel port
+func (b *pciBus) netDevice(pci string) (string, error) {
+	dirs, _ := filepath.Glob(filepath.Join(b.deviceDir(pci), "net", "*"))
+	// virtio-pci puts the netdev under the virtio device
+	virtio, _ := filepath.Glob(filepath.Join(b.deviceDir(pci), "virtio*", "net", "*"))
+	dirs = append(dirs, virtio...)
+	if len(dirs) == 0 {
+		return "", fmt.Errorf("%s has no net device, is it bound to its kernel driver?", pci)
+	}
+	return filepath.Base(dirs[0]), nil
+}
@@ -6,19 +6,92 @@ import (
 	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 )
 
 const (
-	pciDeviceDir = "/sys/bus/pci/devices/"
-	pciDriverDir = "/sys/bus/pci/drivers/"
+	defaultSysfsRoot = "/sys"
+	pciDeviceDir     = "bus/pci/devices"
+	pciDriverDir     = "bus/pci/drivers"
+	pciDriversProbe  = "bus/pci/drivers_probe"
+	// how long a bind or unbind may take to show up in sysfs
+	bindTimeout      = 5 * time.Second
+	bindPollInterval = 10 * time.Millisecond
+	// how long new_id gets to grab the device before it is bound explicitly
+	newIDTimeout = 100 * time.Millisecond
 )
 
+// sysfsWriter writes sysfs attributes, the kernel reacts on writes to bind, unbind, new_id ...
+type sysfsWriter interface {
+	writeFile(path string, data []byte) error
+}
+
+// moduleLoader loads kernel modules
+type moduleLoader interface {
+	load(module string) error
+}
+
+// hostSysfs writes the real sysfs files
+type hostSysfs struct{}
+
+func (hostSysfs) writeFile(path string, data []byte) error {
+	return ioutil.WriteFile(path, data, 0200)
+}
+
+// modprobeLoader loads modules with modprobe
+type modprobeLoader struct{}
+
+func (modprobeLoader) load(driver string) error {
+	log.Printf("loadDriver: %s", driver)
+	log.Printf("depmod -a")
+	cmd := exec.Command("depmod", "-a")
+	if _, err := cmd.Output(); err != nil {
+		return err
+	}
+	log.Printf("modprobe %s", driver)
+	cmd = exec.Command("modprobe", driver)
+	if _, err := cmd.Output(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// pciBus accesses the pci devices and drivers below a sysfs root
+type pciBus struct {
+	root   string
+	writer sysfsWriter
+	loader moduleLoader
+	// configures the VFs of a PF
+	netlink vfLinker
+	// records and restores the kernel net devices
+	netdevs netdevManager
+	// where the port records are persisted, nil for none
+	journal *bindingJournal
+	// the VF count to put back, journaled with the ports, nil if the VFs weren't touched
+	sriov *sriovState
+	// allow vfio without iommu
+	vfioNoIommu bool
+}
+
+func newHostPciBus(root string) *pciBus {
+	return &pciBus{root: root, writer: hostSysfs{}, loader: modprobeLoader{}, netlink: netlinkVfLinker{}, netdevs: hostNetdevs{}}
+}
+
+func (b *pciBus) deviceDir(pci string) string {
+	return filepath.Join(b.root, pciDeviceDir, pci)
+}
+
+func (b *pciBus) driverDir(driver string) string {
+	return filepath.Join(b.root, pciDriverDir, driver)
+}
+
 type pciArray []string
 
-//pci info, 1:1 map to pci array
+// pci info, 1:1 map to pci array
 type pciInfo struct {
 	//driver previously
 	driverPre string
@@ -31,33 +104,21 @@ type pciInfo struct {
 	kmod   string
 	vendor string
 	device string
+	//dpdk uses the kernel driver, like mlx5_core
+	dpdkUseKmod bool
+	//dpdk driver of the driver table
+	dpdk string
+	//kernel net device, restored after the port is back on kmod
+	netdev *netdevState
 }
 
-type kdDrivers struct {
-	kernel string
-	dpdk   string
-}
+var shortPciRE = regexp.MustCompile(`^[0-9a-fA-F]{2}:[0-9a-fA-F]{2}\.[0-7]$`)
 
-type deviceDriverMap map[string]*kdDrivers
-
-var (
-	intelDefaultDrivers = &kdDrivers{kernel: "i40e", dpdk: "vfio-pci"}
-	mlxDefaultDrivers   = &kdDrivers{kernel: "mlx5_core", dpdk: "mlx5_core"}
-)
-
-var vendorDriverMap = map[string]deviceDriverMap{
-	"0x8086": {
-		"default": intelDefaultDrivers,
-	},
-	"0x15b3": {
-		"default": mlxDefaultDrivers,
-	},
-}
-
-//normalize PCI address with prefix 0000:
+// normalize PCI address with prefix 0000:, names that are not a bus:device.function
+// address, like vdev names, are left alone
 func normalizePci(pci string) string {
 	var npci string
-	if !strings.HasPrefix(pci, "0000:") {
+	if shortPciRE.MatchString(pci) {
 		npci = "0000:" + pci
 	} else {
 		npci = pci
@@ -65,31 +126,21 @@ func normalizePci(pci string) string {
 	return npci
 }
 
-func getNumaNode(pci string) (int, error) {
-	numaStr, err := ioutil.ReadFile(pciDeviceDir + pci + "/numa_node")
+func (b *pciBus) getNumaNode(pci string) (int, error) {
+	numaStr, err := ioutil.ReadFile(filepath.Join(b.deviceDir(pci), "numa_node"))
 	if err != nil {
 		return -1, err
 	}
 	return strconv.Atoi(strings.TrimSpace(string(numaStr)))
 }
 
-func loadDriver(driver string) error {
-	log.Printf("loadDriver: %s", driver)
-	log.Printf("depmod -a")
-	cmd := exec.Command("depmod", "-a")
-	if _, err := cmd.Output(); err != nil {
-		return err
-	}
-	log.Printf("modprobe %s", driver)
-	cmd = exec.Command("modprobe", driver)
-	if _, err := cmd.Output(); err != nil {
-		return err
+func (b *pciBus) isKernelDevice(pci string) bool {
+	if _, err := os.Stat(filepath.Join(b.deviceDir(pci), "net")); !os.IsNotExist(err) {
+		log.Printf("isKernelDevice: %s is kernel port", pci)
+		return true
 	}
-	return nil
-}
-
-func isKernelDevice(pci string) bool {
-	if _, err := os.Stat(pciDeviceDir + pci + "/net"); !os.IsNotExist(err) {
+	// virtio-pci puts the netdev under the virtio device
+	if m, _ := filepath.Glob(filepath.Join(b.deviceDir(pci), "virtio*", "net")); len(m) > 0 {
 		log.Printf("isKernelDevice: %s is kernel port", pci)
 		return true
 	}
@@ -97,137 +148,316 @@ func isKernelDevice(pci string) bool {
 	return false
 }
 
-func isDeviceBound(pci string) (bool, string) {
-	driverPath := pciDeviceDir + pci + "/driver"
-	if _, err := os.Stat(driverPath); !os.IsNotExist(err) {
-		cmd := exec.Command("readlink", "-f", driverPath)
-		out, _ := cmd.Output()
-		d := strings.Split(strings.TrimSpace(string(out)), "/")
-		driver := d[len(d)-1]
+// boundDriver returns the driver the device is bound to, empty if none
+func (b *pciBus) boundDriver(pci string) string {
+	target, err := os.Readlink(filepath.Join(b.deviceDir(pci), "driver"))
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(target)
+}
+
+func (b *pciBus) isDeviceBound(pci string) (bool, string) {
+	if driver := b.boundDriver(pci); driver != "" {
 		log.Printf("isDeviceBound: %s is bound to %s", pci, driver)
 		return true, driver
 	}
-	log.Printf("isDeviceBound: %s", driverPath)
 	log.Printf("isDeviceBound: %s not bound", pci)
 	return false, ""
 }
 
-func unbind(pci string) error {
-	driverPath := pciDeviceDir + pci + "/driver"
-	cmd := exec.Command("readlink", "-f", driverPath)
-	out, _ := cmd.Output()
-	log.Printf("unbind: echo %s > %s\n", pci, strings.TrimSpace(string(out))+"/unbind")
-	return ioutil.WriteFile(strings.TrimSpace(string(out))+"/unbind", []byte(pci), 0200)
+// waitDriver polls until the device is bound to driver, or unbound if driver is empty
+func (b *pciBus) waitDriver(pci string, driver string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		cur := b.boundDriver(pci)
+		if cur == driver {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			if driver == "" {
+				return fmt.Errorf("%s still bound to %s after %v", pci, cur, timeout)
+			}
+			return fmt.Errorf("%s not bound to %s after %v, bound to %q", pci, driver, timeout, cur)
+		}
+		time.Sleep(bindPollInterval)
+	}
+}
+
+func (b *pciBus) unbind(pci string) error {
+	driverPath, err := filepath.EvalSymlinks(filepath.Join(b.deviceDir(pci), "driver"))
+	if err != nil {
+		return fmt.Errorf("unbind %s: %v", pci, err)
+	}
+	log.Printf("unbind: echo %s > %s\n", pci, driverPath+"/unbind")
+	if err := b.writer.writeFile(driverPath+"/unbind", []byte(pci)); err != nil {
+		return err
+	}
+	return b.waitDriver(pci, "", bindTimeout)
+}
+
+// hasDriverOverride tells if the kernel has driver_override for the device, since 3.16
+func (b *pciBus) hasDriverOverride(pci string) bool {
+	_, err := os.Stat(filepath.Join(b.deviceDir(pci), "driver_override"))
+	return err == nil
 }
 
-func bind(pci string, driver string) error {
-	driverPath := pciDriverDir + driver
-	log.Printf("bind: echo %s > %s\n", pci, driverPath+"/bind")
-	return ioutil.WriteFile(driverPath+"/bind", []byte(pci), 0200)
+// bind binds an unbound device to driver. With driver_override only this device is probed by the
+// driver, the override is cleared afterwards so the next probe picks the native driver again.
+func (b *pciBus) bind(pci string, driver string) error {
+	if !b.hasDriverOverride(pci) {
+		driverPath := b.driverDir(driver)
+		log.Printf("bind: echo %s > %s\n", pci, driverPath+"/bind")
+		if err := b.writer.writeFile(driverPath+"/bind", []byte(pci)); err != nil {
+			return err
+		}
+		return b.waitDriver(pci, driver, bindTimeout)
+	}
+	overridePath := filepath.Join(b.deviceDir(pci), "driver_override")
+	log.Printf("bind: echo %s > %s\n", driver, overridePath)
+	if err := b.writer.writeFile(overridePath, []byte(driver)); err != nil {
+		return err
+	}
+	defer func() {
+		if err := b.writer.writeFile(overridePath, []byte("\n")); err != nil {
+			log.Printf("bind: failed to clear %s: %v", overridePath, err)
+		}
+	}()
+	probePath := filepath.Join(b.root, pciDriversProbe)
+	log.Printf("bind: echo %s > %s\n", pci, probePath)
+	if err := b.writer.writeFile(probePath, []byte(pci)); err != nil {
+		return err
+	}
+	return b.waitDriver(pci, driver, bindTimeout)
 }
 
-func pciNewID(vendor string, device string, driver string) error {
-	newIDPath := pciDriverDir + driver + "/new_id"
+func (b *pciBus) pciNewID(vendor string, device string, driver string) error {
+	newIDPath := filepath.Join(b.driverDir(driver), "new_id")
 	log.Printf("pciNewID: echo %s %s > %s\n", vendor, device, newIDPath)
-	return ioutil.WriteFile(newIDPath, []byte(vendor+" "+device), 0200)
+	return b.writer.writeFile(newIDPath, []byte(vendor+" "+device))
 }
 
-func pciRemoveID(vendor string, device string, driver string) error {
-	removeIDPath := pciDriverDir + driver + "/remove_id"
+func (b *pciBus) pciRemoveID(vendor string, device string, driver string) error {
+	removeIDPath := filepath.Join(b.driverDir(driver), "remove_id")
 	log.Printf("pciRemoveID: echo %s %s > %s\n", vendor, device, removeIDPath)
-	return ioutil.WriteFile(removeIDPath, []byte(vendor+" "+device), 0200)
+	return b.writer.writeFile(removeIDPath, []byte(vendor+" "+device))
+}
+
+// setupDpdkPorts moves all the ports to their dpdk driver: the one overrides has for the port,
+// else dpdkDriver, else the one of the driver table. Ports whose dpdk driver is the kernel driver
+// stay on it unless overridden. It either succeeds for all of them or rolls every port it changed
+// back to the driver it was on before.
+func (b *pciBus) setupDpdkPorts(dpdkDriver string, overrides map[string]string, pci pciArray, record map[string]*pciInfo) error {
+	log.Printf("setupPorts: %+q\n", pci)
+	// record the state of every port before changing any, binding one port
+	// through new_id may also grab the next one
+	drivers := make(map[string]string)
+	for _, p := range pci {
+		info, err := b.getPciInfo(p)
+		if err != nil {
+			return fmt.Errorf("failed to set up %s: %v", p, err)
+		}
+		if d, ok := overrides[p]; ok {
+			// the port goes to the given driver, even if dpdk could use the kernel one
+			drivers[p] = d
+			info.dpdkUseKmod = d == info.kmod
+		} else if info.dpdkUseKmod {
+			drivers[p] = info.kmod
+		} else if dpdkDriver != "" {
+			drivers[p] = dpdkDriver
+		} else {
+			drivers[p] = info.dpdk
+		}
+		record[p] = info
+	}
+	var loaded []string
+	for _, p := range pci {
+		if containsString(loaded, drivers[p]) {
+			continue
+		}
+		if err := b.loader.load(drivers[p]); err != nil {
+			return err
+		}
+		loaded = append(loaded, drivers[p])
+	}
+	if err := b.checkIommu(pci, drivers); err != nil {
+		return err
+	}
+	// journal the records before the first change, a crash from here on leaves the
+	// next start with what it needs to restore the ports
+	if err := b.saveJournal(pci, record); err != nil {
+		return fmt.Errorf("failed to write journal: %v", err)
+	}
+	for _, p := range pci {
+		log.Printf("setupPorts: %s\n", p)
+		if err := b.setupDpdkPort(drivers[p], p, record[p]); err != nil {
+			err = fmt.Errorf("failed to set up %s: %v", p, err)
+			log.Printf("setupPorts: %v, rolling back", err)
+			// the ports after this one may have moved too, new_id probes every device with the id
+			if rerr := b.rollbackPorts(pci, record); rerr != nil {
+				return fmt.Errorf("%v; rollback failed: %v", err, rerr)
+			}
+			b.removeJournal()
+			return err
+		}
+	}
+	if err := b.saveJournal(pci, record); err != nil {
+		log.Printf("setupPorts: failed to update journal: %v", err)
+	}
+	return nil
+}
+
+func (b *pciBus) saveJournal(pci pciArray, record map[string]*pciInfo) error {
+	if b.journal == nil {
+		return nil
+	}
+	return b.journal.save(b.root, pci, record, b.sriov)
+}
+
+// removeJournal is called once the ports are restored, the journal is kept for the VF count
+// until restoreSriov
+func (b *pciBus) removeJournal() {
+	if b.journal == nil {
+		return
+	}
+	if b.sriov != nil {
+		if err := b.saveJournal(nil, nil); err != nil {
+			log.Printf("failed to update journal: %v", err)
+		}
+		return
+	}
+	if err := b.journal.remove(); err != nil {
+		log.Printf("failed to remove journal: %v", err)
+	}
 }
 
-func getDriverFromDeviceVendor(vendor string, device string) (*kdDrivers, error) {
-	deviceMap, ok := vendorDriverMap[vendor]
+// getPciInfo returns the drivers of a port and whether it is a kernel port
+func (b *pciBus) getPciInfo(p string) (*pciInfo, error) {
+	info := &pciInfo{}
+	out, _ := ioutil.ReadFile(filepath.Join(b.deviceDir(p), "vendor"))
+	info.vendor = strings.TrimSpace(string(out))
+	out, _ = ioutil.ReadFile(filepath.Join(b.deviceDir(p), "device"))
+	info.device = strings.TrimSpace(string(out))
+	bound, driver := b.isDeviceBound(p)
+	kernelPort := bound && b.isKernelDevice(p)
+	drivers, ok := getDriverFromDeviceVendor(info.vendor, info.device)
 	if !ok {
-		return nil, fmt.Errorf("device driver map not defined for vendor %s", vendor)
+		// unknown device, assume the driver it is on is its kernel driver
+		drivers = &kdDrivers{dpdk: fallbackDpdkDriver}
+		if kernelPort {
+			drivers.kernel = driver
+		}
+		log.Printf("no driver table entry for %s (%s:%s), kernel driver %q, dpdk driver %s",
+			p, info.vendor, info.device, drivers.kernel, drivers.dpdk)
 	}
-	driver, ok := deviceMap[device]
-	if ok {
-		return driver, nil
+	info.kmod = drivers.kernel
+	info.dpdk = drivers.dpdk
+	// does this device use kmod as dpdk driver
+	if drivers.dpdk == drivers.kernel {
+		log.Printf("dpdk use the same driver as kernel %s", drivers.kernel)
+		info.dpdkUseKmod = true
 	}
-	// fall back to default driver for this vendor
-	driver, ok = deviceMap["default"]
-	if ok {
-		return driver, nil
+	if bound {
+		info.driverPre = driver
+		if kernelPort {
+			info.kmod = driver
+			info.wasKernelPort = true
+			info.netdev = b.snapshotNetdev(p)
+		}
 	}
-	return nil, fmt.Errorf("default driver not defined for vendor %s", vendor)
+	return info, nil
 }
 
-func setupDpdkPorts(dpdkDriver string, pci pciArray, record map[string]*pciInfo) error {
-	log.Printf("setupPorts: %+q\n", pci)
-	if err := loadDriver(dpdkDriver); err != nil {
+func (b *pciBus) setupDpdkPort(dpdkDriver string, p string, info *pciInfo) error {
+	bound, driver := b.isDeviceBound(p)
+	if bound {
+		if b.isKernelDevice(p) {
+			// if dpdk use kmod, skip the rest
+			if info.dpdkUseKmod {
+				info.driverCur = driver
+				return nil
+			}
+		} else if driver == dpdkDriver {
+			// already on dpdk driver, skip
+			info.driverCur = dpdkDriver
+			return nil
+		}
+		// unbind first
+		if err := b.unbind(p); err != nil {
+			return err
+		}
+	}
+	if info.dpdkUseKmod {
+		// if dpdk use kernel driver, ignore the dpdk driver parameter
+		if err := b.bind(p, info.kmod); err != nil {
+			return err
+		}
+		info.driverCur = info.kmod
+		return nil
+	}
+	if b.hasDriverOverride(p) {
+		if err := b.bind(p, dpdkDriver); err != nil {
+			return err
+		}
+		info.driverCur = dpdkDriver
+		return nil
+	}
+	// kernels without driver_override: the driver only takes the device once it knows its id,
+	// new_id also makes it probe every other unbound device with this id
+	log.Printf("setupPorts: no driver_override for %s, using new_id", p)
+	if err := b.pciNewID(info.vendor, info.device, dpdkDriver); err != nil {
 		return err
 	}
-
-	for _, p := range pci {
-		log.Printf("setupPorts: %s\n", p)
-		info := &pciInfo{}
-		record[p] = info
-		info.wasKernelPort = false
-		out, _ := ioutil.ReadFile(pciDeviceDir + p + "/vendor")
-		info.vendor = strings.TrimSpace(string(out))
-		out, _ = ioutil.ReadFile(pciDeviceDir + p + "/device")
-		info.device = strings.TrimSpace(string(out))
-		drivers, err := getDriverFromDeviceVendor(info.vendor, info.device)
-		if err != nil {
-			log.Fatal(err)
+	defer b.pciRemoveID(info.vendor, info.device, dpdkDriver)
+	if err := b.waitDriver(p, dpdkDriver, newIDTimeout); err != nil {
+		// bind the driver only if new_id didn't do the trick
+		if err := b.bind(p, dpdkDriver); err != nil {
+			return err
 		}
-		info.kmod = drivers.kernel
-		// does this device use kmod as dpdk driver
-		dpdkUseKmod := false
-		if drivers.dpdk == drivers.kernel {
-			log.Printf("dpdk use the same driver as kernel %s", drivers.kernel)
-			dpdkUseKmod = true
+	}
+	info.driverCur = dpdkDriver
+	return nil
+}
+
+// rollbackPorts puts the ports back on the driver recorded in driverPre, last port first
+func (b *pciBus) rollbackPorts(pci pciArray, record map[string]*pciInfo) error {
+	var failed []string
+	for i := len(pci) - 1; i >= 0; i-- {
+		p := pci[i]
+		info := record[p]
+		bound, driver := b.isDeviceBound(p)
+		if driver == info.driverPre {
+			delete(record, p)
+			continue
 		}
-		bound, driver := isDeviceBound(p)
+		log.Printf("rollback: %s from %q to %q\n", p, driver, info.driverPre)
 		if bound {
-			info.driverPre = driver
-			if isKernelDevice(p) {
-				info.kmod = driver
-				info.wasKernelPort = true
-				// if dpdk use kmod, skip the rest
-				if dpdkUseKmod {
-					info.driverCur = driver
-					continue
-				}
-			} else if driver == dpdkDriver {
-				// already on dpdk driver, skip
-				info.driverCur = dpdkDriver
+			if err := b.unbind(p); err != nil {
+				failed = append(failed, fmt.Sprintf("%s: %v", p, err))
 				continue
 			}
-			// unbind first
-			unbind(p)
 		}
-		if dpdkUseKmod {
-			// if dpdk use kernel driver, ignore the dpdk driver parameter
-			if err := bind(p, drivers.kernel); err != nil {
-				return err
+		if info.driverPre != "" {
+			if err := b.bind(p, info.driverPre); err != nil {
+				failed = append(failed, fmt.Sprintf("%s: %v", p, err))
+				continue
 			}
-			info.driverCur = drivers.kernel
-			continue
-		}
-		// otherwise, set up new_id if not done yet
-		if err := pciNewID(info.vendor, info.device, dpdkDriver); err != nil {
-			return err
-		}
-		// small sleep to get new_id kick in
-		time.Sleep(20 * time.Millisecond)
-		if success, _ := isDeviceBound(p); !success {
-			// bind the driver only if new_id didn't do the trick
-			if err := bind(p, dpdkDriver); err != nil {
-				return err
+			if info.wasKernelPort {
+				b.restoreNetdev(p, info.netdev)
 			}
 		}
-		info.driverCur = dpdkDriver
-		pciRemoveID(info.vendor, info.device, dpdkDriver)
-		time.Sleep(20 * time.Millisecond)
+		delete(record, p)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%s", strings.Join(failed, ", "))
 	}
 	return nil
 }
 
-func restoreKernalPorts(pci pciArray, record map[string]*pciInfo) error {
+// restoreKernalPorts puts the kernel ports back on their kernel driver. A port that fails doesn't
+// stop the others, the journal is kept until all of them are back.
+func (b *pciBus) restoreKernalPorts(pci pciArray, record map[string]*pciInfo) error {
+	var failed []string
 	for _, p := range pci {
 		if record[p].driverCur == record[p].kmod {
 			// mlnx like case, kernel driver is used by dpdk
@@ -235,15 +465,20 @@ func restoreKernalPorts(pci pciArray, record map[string]*pciInfo) error {
 		}
 		if record[p].wasKernelPort {
 			log.Printf("bind %s to %s\n", p, record[p].kmod)
-			if err := unbind(p); err != nil {
-				return err
+			if err := b.unbind(p); err != nil {
+				failed = append(failed, fmt.Sprintf("%s: %v", p, err))
+				continue
 			}
-			time.Sleep(20 * time.Millisecond)
-			if err := bind(p, record[p].kmod); err != nil {
-				return err
+			if err := b.bind(p, record[p].kmod); err != nil {
+				failed = append(failed, fmt.Sprintf("%s: %v", p, err))
+				continue
 			}
-			time.Sleep(20 * time.Millisecond)
+			b.restoreNetdev(p, record[p].netdev)
 		}
 	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%s", strings.Join(failed, ", "))
+	}
+	b.removeJournal()
 	return nil
 }
@@ -0,0 +1,453 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	intelPort    = "0000:86:00.0"
+	intelPort2   = "0000:86:00.1"
+	unboundPort  = "0000:86:00.2"
+	vfioPort     = "0000:86:00.3"
+	mlxPort      = "0000:3b:00.0"
+	unknownPort  = "0000:5e:00.0"
+	unknownNetDr = "foo_net"
+)
+
+// newPortsSysfs returns a fake sysfs with X710 ports on i40e, unbound and on vfio-pci,
+// a ConnectX-5 port on mlx5_core and a port of a vendor missing from the driver table
+func newPortsSysfs(t *testing.T) *fakeSysfs {
+	t.Helper()
+	f, err := newFakeSysfs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range []string{"i40e", "mlx5_core", unknownNetDr} {
+		if err := f.addDriver(d, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.load(vfioDriver); err != nil {
+		t.Fatal(err)
+	}
+	devices := []struct{ pci, vendor, device, driver, ifName string }{
+		{intelPort, "0x8086", "0x1572", "i40e", "ens1f0"},
+		{intelPort2, "0x8086", "0x1572", "i40e", "ens1f1"},
+		{unboundPort, "0x8086", "0x1572", "", "ens1f2"},
+		{vfioPort, "0x8086", "0x1572", vfioDriver, "ens1f3"},
+		{mlxPort, "0x15b3", "0x1017", "mlx5_core", "ens3f0"},
+		{unknownPort, "0x1234", "0x5678", unknownNetDr, "eth9"},
+	}
+	for i, d := range devices {
+		if err := f.addDevice(d.pci, d.vendor, d.device, 0, d.driver, d.ifName); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.addIommuGroup(simIommuGroup+i, d.pci); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return f
+}
+
+func checkDriver(t *testing.T, b *pciBus, pci string, want string) {
+	t.Helper()
+	if got := b.boundDriver(pci); got != want {
+		t.Errorf("%s is on %q, want %q", pci, got, want)
+	}
+}
+
+func TestSetupAndRestorePorts(t *testing.T) {
+	tests := []struct {
+		name string
+		pci  string
+		// the dpdk driver given to setupDpdkPorts, the one of the driver table if empty
+		dpdkDriver string
+		want       pciInfo
+		// the driver after restoreKernalPorts
+		wantRestored string
+	}{
+		{
+			name:         "intel",
+			pci:          intelPort,
+			want:         pciInfo{driverPre: "i40e", driverCur: vfioDriver, kmod: "i40e", wasKernelPort: true},
+			wantRestored: "i40e",
+		},
+		{
+			name:         "mellanox",
+			pci:          mlxPort,
+			want:         pciInfo{driverPre: "mlx5_core", driverCur: "mlx5_core", kmod: "mlx5_core", wasKernelPort: true, dpdkUseKmod: true},
+			wantRestored: "mlx5_core",
+		},
+		{
+			name:         "already on vfio-pci",
+			pci:          vfioPort,
+			want:         pciInfo{driverPre: vfioDriver, driverCur: vfioDriver, kmod: "i40e"},
+			wantRestored: vfioDriver,
+		},
+		{
+			// only kernel ports go back, the port stays on vfio-pci
+			name:         "unbound",
+			pci:          unboundPort,
+			want:         pciInfo{driverCur: vfioDriver, kmod: "i40e"},
+			wantRestored: vfioDriver,
+		},
+		{
+			name:         "unknown vendor",
+			pci:          unknownPort,
+			want:         pciInfo{driverPre: unknownNetDr, driverCur: fallbackDpdkDriver, kmod: unknownNetDr, wasKernelPort: true},
+			wantRestored: unknownNetDr,
+		},
+		{
+			name:         "dpdk driver given",
+			pci:          intelPort,
+			dpdkDriver:   "igb_uio",
+			want:         pciInfo{driverPre: "i40e", driverCur: "igb_uio", kmod: "i40e", wasKernelPort: true},
+			wantRestored: "i40e",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newPortsSysfs(t)
+			defer f.remove()
+			b := f.bus()
+			pci := pciArray{tt.pci}
+			record := make(map[string]*pciInfo)
+			if err := b.setupDpdkPorts(tt.dpdkDriver, nil, pci, record); err != nil {
+				t.Fatal(err)
+			}
+			info := record[tt.pci]
+			if info == nil {
+				t.Fatalf("no record of %s", tt.pci)
+			}
+			got := pciInfo{driverPre: info.driverPre, driverCur: info.driverCur, kmod: info.kmod,
+				wasKernelPort: info.wasKernelPort, dpdkUseKmod: info.dpdkUseKmod}
+			if got != tt.want {
+				t.Errorf("record %+v, want %+v", got, tt.want)
+			}
+			if (info.netdev != nil) != tt.want.wasKernelPort {
+				t.Errorf("net device recorded: %v, kernel port: %v", info.netdev != nil, tt.want.wasKernelPort)
+			}
+			checkDriver(t, b, tt.pci, tt.want.driverCur)
+			if err := b.restoreKernalPorts(pci, record); err != nil {
+				t.Fatal(err)
+			}
+			checkDriver(t, b, tt.pci, tt.wantRestored)
+		})
+	}
+}
+
+// failingWriter fails the first write to path
+type failingWriter struct {
+	sysfsWriter
+	path string
+}
+
+func (w *failingWriter) writeFile(path string, data []byte) error {
+	if path == w.path {
+		w.path = ""
+		return fmt.Errorf("write %s: permission denied", path)
+	}
+	return w.sysfsWriter.writeFile(path, data)
+}
+
+// droppingWriter ignores the writes to path, like a driver that doesn't take a device on new_id
+type droppingWriter struct {
+	sysfsWriter
+	path string
+}
+
+func (w *droppingWriter) writeFile(path string, data []byte) error {
+	if path == w.path {
+		return nil
+	}
+	return w.sysfsWriter.writeFile(path, data)
+}
+
+// Kernels before 3.16 have no driver_override, vfio-pci gets the port through new_id or, if
+// that doesn't bind it, through its bind file, and the port goes back through the bind file
+// of its kernel driver
+func TestSetupPortsWithoutDriverOverride(t *testing.T) {
+	tests := []struct {
+		name string
+		pci  string
+		// the ports keep their driver_override
+		override bool
+		// vfio-pci doesn't take the port on new_id
+		dropNewID bool
+		// the driver of the ports after setupDpdkPorts
+		want map[string]string
+		// the driver of the port after restoreKernalPorts
+		wantRestored string
+	}{
+		{
+			name:         "kernel port",
+			pci:          intelPort,
+			want:         map[string]string{intelPort: vfioDriver, intelPort2: "i40e"},
+			wantRestored: "i40e",
+		},
+		{
+			name:         "unbound port",
+			pci:          unboundPort,
+			want:         map[string]string{unboundPort: vfioDriver},
+			wantRestored: vfioDriver,
+		},
+		{
+			name:         "bind after new_id",
+			pci:          intelPort,
+			dropNewID:    true,
+			want:         map[string]string{intelPort: vfioDriver, unboundPort: ""},
+			wantRestored: "i40e",
+		},
+		{
+			// new_id probes every unbound device with the id
+			name:         "other unbound port grabbed",
+			pci:          intelPort,
+			want:         map[string]string{intelPort: vfioDriver, unboundPort: vfioDriver},
+			wantRestored: "i40e",
+		},
+		{
+			name:         "driver_override",
+			pci:          intelPort,
+			override:     true,
+			want:         map[string]string{intelPort: vfioDriver, unboundPort: ""},
+			wantRestored: "i40e",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newPortsSysfs(t)
+			defer f.remove()
+			b := f.bus()
+			if !tt.override {
+				for _, p := range []string{intelPort, intelPort2, unboundPort, vfioPort} {
+					if err := os.Remove(filepath.Join(b.deviceDir(p), "driver_override")); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			if tt.dropNewID {
+				b.writer = &droppingWriter{sysfsWriter: f, path: filepath.Join(b.driverDir(vfioDriver), "new_id")}
+			}
+			pci := pciArray{tt.pci}
+			record := make(map[string]*pciInfo)
+			if err := b.setupDpdkPorts("", nil, pci, record); err != nil {
+				t.Fatal(err)
+			}
+			for p, want := range tt.want {
+				checkDriver(t, b, p, want)
+			}
+			if ids := f.newIDs[vfioDriver]; len(ids) != 0 {
+				t.Errorf("ids %+q left on %s", ids, vfioDriver)
+			}
+			if err := b.restoreKernalPorts(pci, record); err != nil {
+				t.Fatal(err)
+			}
+			checkDriver(t, b, tt.pci, tt.wantRestored)
+		})
+	}
+}
+
+// Without driver_override, new_id makes vfio-pci take every unbound port with the id, the ports
+// after the one that fails are moved too and must be rolled back as well.
+func TestSetupPortsRollback(t *testing.T) {
+	f := newPortsSysfs(t)
+	defer f.remove()
+	b := f.bus()
+	for _, p := range []string{intelPort, unboundPort} {
+		if err := os.Remove(filepath.Join(b.deviceDir(p), "driver_override")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b.writer = &failingWriter{sysfsWriter: f, path: filepath.Join(b.deviceDir(intelPort2), "driver_override")}
+	pci := pciArray{intelPort, intelPort2, unboundPort}
+	record := make(map[string]*pciInfo)
+	err := b.setupDpdkPorts("", nil, pci, record)
+	if err == nil || !strings.Contains(err.Error(), "failed to set up "+intelPort2) {
+		t.Fatalf("error %v, want the failure of %s", err, intelPort2)
+	}
+	checkDriver(t, b, intelPort, "i40e")
+	checkDriver(t, b, intelPort2, "i40e")
+	checkDriver(t, b, unboundPort, "")
+}
+
+func TestStaleJournal(t *testing.T) {
+	f := newPortsSysfs(t)
+	defer f.remove()
+	dir, err := ioutil.TempDir("", "journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	journal := &bindingJournal{path: filepath.Join(dir, "bindings.json")}
+	b := f.bus()
+	b.journal = journal
+	pci := pciArray{intelPort, mlxPort, unboundPort}
+	if err := b.setupDpdkPorts("", nil, pci, make(map[string]*pciInfo)); err != nil {
+		t.Fatal(err)
+	}
+	root, journaled, record, sriov, err := journal.load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root != f.root || len(journaled) != 3 || record[intelPort].driverCur != vfioDriver || sriov != nil {
+		t.Fatalf("journal of %s: %+q %+v %+v", root, journaled, record, sriov)
+	}
+
+	// another sysfs root is refused
+	other := &pciBus{root: "/sys", journal: journal}
+	if err := other.restoreFromJournal(); err == nil {
+		t.Error("journal restored against another sysfs")
+	}
+
+	// the wrapper was killed, the next start puts the ports back on their driver
+	next := f.bus()
+	next.journal = journal
+	if err := next.restoreFromJournal(); err != nil {
+		t.Fatal(err)
+	}
+	checkDriver(t, next, intelPort, "i40e")
+	checkDriver(t, next, mlxPort, "mlx5_core")
+	checkDriver(t, next, unboundPort, "")
+	if _, err := os.Stat(journal.path); !os.IsNotExist(err) {
+		t.Errorf("journal not removed: %v", err)
+	}
+	// nothing left to restore
+	if err := next.restoreFromJournal(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(journal.path, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := next.restoreFromJournal(); err == nil || !strings.Contains(err.Error(), "corrupted journal") {
+		t.Errorf("corrupted journal restored: %v", err)
+	}
+}
+
+// a port that can't go back doesn't keep the next ones on vfio-pci, the journal stays for a retry
+func TestRestorePortsContinues(t *testing.T) {
+	f := newPortsSysfs(t)
+	defer f.remove()
+	dir, err := ioutil.TempDir("", "journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	b := f.bus()
+	b.journal = &bindingJournal{path: filepath.Join(dir, "bindings.json")}
+	pci := pciArray{intelPort, intelPort2, unknownPort}
+	record := make(map[string]*pciInfo)
+	if err := b.setupDpdkPorts("", nil, pci, record); err != nil {
+		t.Fatal(err)
+	}
+	b.writer = &failingWriter{sysfsWriter: f, path: filepath.Join(b.driverDir(vfioDriver), "unbind")}
+	err = b.restoreKernalPorts(pci, record)
+	if err == nil || !strings.Contains(err.Error(), intelPort) {
+		t.Fatalf("error %v, want the failure of %s", err, intelPort)
+	}
+	checkDriver(t, b, intelPort, vfioDriver)
+	checkDriver(t, b, intelPort2, "i40e")
+	checkDriver(t, b, unknownPort, unknownNetDr)
+	if _, err := os.Stat(b.journal.path); err != nil {
+		t.Errorf("journal removed after a failed restore: %v", err)
+	}
+}
+
+func TestJournalRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	// the directory is created on the first save
+	j := &bindingJournal{path: filepath.Join(dir, "testpmd-wrapper", "bindings.json")}
+	pci := pciArray{intelPort, unboundPort}
+	record := map[string]*pciInfo{
+		intelPort: {driverPre: "i40e", driverCur: vfioDriver, wasKernelPort: true, kmod: "i40e",
+			vendor: "0x8086", device: "0x1572", netdev: &netdevState{Name: "ens1f0", Mtu: 9000, Up: true,
+				Addrs: []string{"192.168.1.1/24"}, Routes: []routeState{{Gw: "192.168.1.254"}}}},
+		unboundPort: {driverCur: vfioDriver, kmod: "i40e", vendor: "0x8086", device: "0x1572"},
+		// not one of the ports, left out
+		mlxPort: {driverPre: "mlx5_core"},
+	}
+	sriov := &sriovState{pf: testPf, numVfs: 2, vfs: []vfSettings{{Vf: 1, Mac: "02:00:00:00:04:01", Vlan: 11, Trust: true}}}
+	if err := j.save("/sys", pci, record, sriov); err != nil {
+		t.Fatal(err)
+	}
+	root, gotPci, gotRecord, gotSriov, err := j.load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	delete(record, mlxPort)
+	if root != "/sys" || !reflect.DeepEqual(gotPci, pci) || !reflect.DeepEqual(gotRecord, record) ||
+		!reflect.DeepEqual(gotSriov, sriov) {
+		t.Errorf("loaded %s %+q %+v %+v, want the saved records", root, gotPci, gotRecord, gotSriov)
+	}
+	if err := j.remove(); err != nil {
+		t.Fatal(err)
+	}
+	// no journal, nothing to restore
+	root, gotPci, gotRecord, gotSriov, err = j.load()
+	if err != nil || root != "" || gotPci != nil || len(gotRecord) != 0 || gotSriov != nil {
+		t.Errorf("loaded %s %+q %+v %+v %v without a journal", root, gotPci, gotRecord, gotSriov, err)
+	}
+}
+
+// the restore command retries until the ports and the VFs are all back, the journal is kept till then
+func TestRestoreFromJournalRetry(t *testing.T) {
+	f := newSriovSysfs(t, 0)
+	defer f.remove()
+	if err := f.load(vfioDriver); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.addDevice(intelPort, "0x8086", "0x1572", 0, "i40e", "ens1f0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.addIommuGroup(simIommuGroup+8, intelPort); err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	journal := &bindingJournal{path: filepath.Join(dir, "bindings.json")}
+	b := f.bus()
+	b.journal = journal
+	vfs, _, err := b.setupSriov(&sriovConfig{pf: testPf, numVfs: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pci := append(pciArray{intelPort}, vfs...)
+	if err := b.setupDpdkPorts("", nil, pci, make(map[string]*pciInfo)); err != nil {
+		t.Fatal(err)
+	}
+
+	next := f.bus()
+	next.journal = journal
+	next.writer = &failingWriter{sysfsWriter: f, path: filepath.Join(next.driverDir(vfioDriver), "unbind")}
+	if err := next.restoreFromJournal(); err == nil {
+		t.Fatal("restored with a port failing to unbind")
+	}
+	if _, err := os.Stat(journal.path); err != nil {
+		t.Fatalf("journal removed after a failed restore: %v", err)
+	}
+	checkNumVfs(t, next, 2)
+
+	next = f.bus()
+	next.journal = journal
+	if err := next.restoreFromJournal(); err != nil {
+		t.Fatal(err)
+	}
+	checkDriver(t, next, intelPort, "i40e")
+	checkNumVfs(t, next, 0)
+	if _, err := os.Stat(journal.path); !os.IsNotExist(err) {
+		t.Errorf("journal not removed: %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// "port start all" waits for the links to come up
+const portCmdTimeout = 15 * time.Second
+
+// portSettings are the queues, ring sizes and mtu of all ports, zero if unknown
+type portSettings struct {
+	rxq int
+	txq int
+	rxd int
+	txd int
+	mtu int
+}
+
+// merge returns s with the non zero settings of o
+func (s portSettings) merge(o portSettings) portSettings {
+	if o.rxq != 0 {
+		s.rxq = o.rxq
+	}
+	if o.txq != 0 {
+		s.txq = o.txq
+	}
+	if o.rxd != 0 {
+		s.rxd = o.rxd
+	}
+	if o.txd != 0 {
+		s.txd = o.txd
+	}
+	if o.mtu != 0 {
+		s.mtu = o.mtu
+	}
+	return s
+}
+
+// cmdsFrom returns the commands that take stopped ports from cur to s
+func (s portSettings) cmdsFrom(cur portSettings, ports int) []string {
+	var cmds []string
+	for _, c := range []struct {
+		name     string
+		val, cur int
+	}{
+		{"rxq", s.rxq, cur.rxq},
+		{"txq", s.txq, cur.txq},
+		{"rxd", s.rxd, cur.rxd},
+		{"txd", s.txd, cur.txd},
+	} {
+		if c.val != c.cur {
+			cmds = append(cmds, fmt.Sprintf("port config all %s %d", c.name, c.val))
+		}
+	}
+	if s.mtu != cur.mtu {
+		for i := 0; i < ports; i++ {
+			cmds = append(cmds, fmt.Sprintf("port config mtu %d %d", i, s.mtu))
+		}
+	}
+	return cmds
+}
+
+// configurePorts applies the non zero settings of want: it stops forwarding and the ports,
+// configures them and starts them again, then forwarding is started again in the same mode if it
+// was running. If testpmd rejects a step, the previous settings are put back. It returns the
+// settings the ports end up with, zero if they are unknown because the previous settings couldn't
+// be put back either.
+func (t *testpmd) configurePorts(ctx context.Context, want portSettings) (portSettings, error) {
+	t.opMu.Lock()
+	defer t.opMu.Unlock()
+	t.stateMu.Lock()
+	prev := t.portCfg
+	t.stateMu.Unlock()
+	next := prev.merge(want)
+	if next == prev {
+		return prev, nil
+	}
+	if t.cores.pinned && (next.rxq != prev.rxq || next.txq != prev.txq) {
+		return prev, invalidArgument("the queues are fixed by the queue map")
+	}
+	if err := ctx.Err(); err != nil {
+		return prev, err
+	}
+	// once the ports are stopped, finish even if the caller goes away
+	ctx = context.Background()
+	log.Printf("configurePorts: %+v to %+v", prev, next)
+	wasRunning := t.running
+	if wasRunning {
+		if _, err := t.runCmd(ctx, "stop"); err != nil {
+			return prev, err
+		}
+		t.setRunning(false)
+	}
+	err := t.applyPortCmds(ctx, next.cmdsFrom(prev, len(t.ports)))
+	if err != nil {
+		log.Printf("configurePorts: %v, rolling back to %+v", err, prev)
+		if rerr := t.applyPortCmds(ctx, prev.cmdsFrom(next, len(t.ports))); rerr != nil {
+			return portSettings{}, t.recoverPorts(ctx, wasRunning, fmt.Errorf("%v, and the rollback failed: %v", err, rerr))
+		}
+		next = prev
+	}
+	t.stateMu.Lock()
+	t.portCfg = next
+	t.stateMu.Unlock()
+	if wasRunning {
+		if serr := t.runStart(ctx); serr != nil && err == nil {
+			err = serr
+		}
+	}
+	return next, err
+}
+
+// recoverPorts is called once neither the new nor the previous settings could be applied: the
+// settings are marked unknown and the ports and forwarding are brought back up if possible
+func (t *testpmd) recoverPorts(ctx context.Context, wasRunning bool, err error) error {
+	log.Printf("configurePorts: %v, port settings unknown", err)
+	t.stateMu.Lock()
+	t.portCfg = portSettings{}
+	t.stateMu.Unlock()
+	if serr := t.runSetCmdTimeout(ctx, "port start all", portCmdTimeout); serr != nil {
+		return fmt.Errorf("%w and the ports may be down (%v): %v", errPortsUnknown, serr, err)
+	}
+	if wasRunning {
+		if serr := t.runStart(ctx); serr != nil {
+			return fmt.Errorf("%w and forwarding is stopped (%v): %v", errPortsUnknown, serr, err)
+		}
+	}
+	return fmt.Errorf("%w: %v", errPortsUnknown, err)
+}
+
+// applyPortCmds runs cmds between "port stop all" and "port start all"
+func (t *testpmd) applyPortCmds(ctx context.Context, cmds []string) error {
+	if err := t.runSetCmdTimeout(ctx, "port stop all", portCmdTimeout); err != nil {
+		return err
+	}
+	for _, cmd := range cmds {
+		if err := t.runSetCmd(ctx, cmd); err != nil {
+			return err
+		}
+	}
+	return t.runSetCmdTimeout(ctx, "port start all", portCmdTimeout)
+}
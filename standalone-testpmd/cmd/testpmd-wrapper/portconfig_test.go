@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+// rejectSession answers the given commands with "Bad arguments"
+type rejectSession struct {
+	*simSession
+	reject []string
+}
+
+func (s *rejectSession) Send(in string) error {
+	cmd := strings.TrimSpace(in)
+	if !containsString(s.reject, cmd) {
+		return s.simSession.Send(in)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.out.WriteString(cmd + "\nBad arguments\ntestpmd> ")
+	return nil
+}
+
+func TestConfigurePorts(t *testing.T) {
+	started := portSettings{rxq: 1, txq: 1, rxd: 512, txd: 512, mtu: 1500}
+	tests := []struct {
+		name   string
+		want   portSettings
+		reject []string
+		// settings after the call
+		wantCfg  portSettings
+		wantCode codes.Code
+		// forwarding is running after the call
+		wantRunning bool
+	}{
+		{
+			name:        "applied",
+			want:        portSettings{rxd: 1024, mtu: 9000},
+			wantCfg:     portSettings{rxq: 1, txq: 1, rxd: 1024, txd: 512, mtu: 9000},
+			wantCode:    codes.OK,
+			wantRunning: true,
+		},
+		{
+			// the ring size is only checked by "port start all"
+			name:        "rolled back",
+			want:        portSettings{rxd: 100},
+			wantCfg:     started,
+			wantCode:    codes.InvalidArgument,
+			wantRunning: true,
+		},
+		{
+			name:        "rollback failed, ports started",
+			want:        portSettings{rxd: 100, mtu: 9000},
+			reject:      []string{"port config mtu 0 1500"},
+			wantCode:    codes.Internal,
+			wantRunning: true,
+		},
+		{
+			name:     "rollback failed, ports down",
+			want:     portSettings{rxd: 100},
+			reject:   []string{"port config all rxd 512"},
+			wantCode: codes.Internal,
+		},
+		{
+			name:     "not restarted",
+			want:     portSettings{mtu: 9000},
+			reject:   []string{"start"},
+			wantCfg:  portSettings{rxq: 1, txq: 1, rxd: 512, txd: 512, mtu: 9000},
+			wantCode: codes.FailedPrecondition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tp, stop := newSimTestpmd(t)
+			defer stop()
+			ctx := context.Background()
+			if err := tp.startFwd(ctx); err != nil {
+				t.Fatal(err)
+			}
+			// a simulated testpmd in the same state that rejects some commands
+			sim := newSimSession(tp.cmdline)
+			sim.running = true
+			tp.x.close()
+			tp.x = newTestExecutor(t, &rejectSession{simSession: sim, reject: tt.reject})
+
+			cfg, err := tp.configurePorts(ctx, tt.want)
+			checkCode(t, grpcError(err), tt.wantCode)
+			if cfg != tt.wantCfg {
+				t.Errorf("settings %+v, want %+v", cfg, tt.wantCfg)
+			}
+			if run := tp.getRunInfo(); run.ports != tt.wantCfg {
+				t.Errorf("reported settings %+v, want %+v", run.ports, tt.wantCfg)
+			}
+			if _, running, _ := tp.getState(); running != tt.wantRunning || sim.running != tt.wantRunning {
+				t.Errorf("running %v, testpmd forwarding %v, want %v", running, sim.running, tt.wantRunning)
+			}
+		})
+	}
+}
@@ -0,0 +1,490 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	expect "github.com/google/goexpect"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpuset"
+)
+
+const (
+	// simulated packet rate and size of every port while forwarding
+	simPps    = 1000000
+	simPktLen = 64
+	// two numa nodes of 16 physical cores with 2 threads each, cpu n and n+32 are siblings
+	simCpus  = 64
+	simNodes = 2
+	// free 2MB huge pages per numa node
+	simHugepages      = 2048
+	simHugepageSizeKB = 2048
+	simVersion        = "20.11.3"
+	// iommu group of the first port, every port has its own
+	simIommuGroup = 40
+	simVfs        = 64
+	// limits of an i40e port
+	simMaxQueues = 64
+	simMinRing   = 64
+	simMaxRing   = 4096
+	simMaxMtu    = 9702
+	// how often Expect looks for new output
+	simPollInterval = 10 * time.Millisecond
+)
+
+var (
+	simPortRE    = regexp.MustCompile(`\s(?:-w|-a)\s+(\S+)`)
+	simLcoresRE  = regexp.MustCompile(`\s-l\s+(\S+)`)
+	simMainRE    = regexp.MustCompile(`--(?:master|main)-lcore\s+(\d+)`)
+	simPortCfgRE = regexp.MustCompile(`--(rxq|txq|rxd|txd)=(\d+)`)
+	simVdevRE    = regexp.MustCompile(`--vdev\s+(\S+)`)
+	// driver of a vdev, "net_null" of "net_null0"
+	simVdevDriverRE = regexp.MustCompile(`^(.*?)\d*$`)
+	simModes        = []string{"io", "mac", "macswap", "flowgen", "rxonly", "txonly", "csum", "icmpecho", "noisy", "5tswap"}
+)
+
+// simBackend emulates the testpmd prompt and outputs, so the wrapper runs without DPDK or NICs.
+// The ports are Intel NICs on the kernel i40e driver in a fake sysfs tree.
+type simBackend struct {
+	sysfs *fakeSysfs
+}
+
+func newSimBackend(pci pciArray, pf string) (*simBackend, error) {
+	sysfs, err := newFakeSysfs()
+	if err != nil {
+		return nil, err
+	}
+	for _, driver := range []string{"i40e", "iavf"} {
+		if err := sysfs.addDriver(driver, true); err != nil {
+			return nil, err
+		}
+	}
+	for node := 0; node < simNodes; node++ {
+		if err := sysfs.addNumaNode(node, simHugepageSizeKB, simHugepages); err != nil {
+			return nil, err
+		}
+	}
+	cores := simCpus / 2
+	for cpu := 0; cpu < simCpus; cpu++ {
+		core := cpu % cores
+		if err := sysfs.addCpu(cpu, core*simNodes/cores, cpuset.NewCPUSet(core, core+cores)); err != nil {
+			return nil, err
+		}
+	}
+	for i, p := range pci {
+		if err := sysfs.addDevice(p, "0x8086", "0x1572", 0, "i40e", fmt.Sprintf("ens1f%d", i)); err != nil {
+			return nil, err
+		}
+		if err := sysfs.addIommuGroup(simIommuGroup+i, p); err != nil {
+			return nil, err
+		}
+	}
+	// a PF with up to simVfs X710 VFs
+	if pf != "" {
+		if err := sysfs.addDevice(pf, "0x8086", "0x1572", 0, "i40e", "ens2f0"); err != nil {
+			return nil, err
+		}
+		if err := sysfs.addIommuGroup(simIommuGroup+len(pci), pf); err != nil {
+			return nil, err
+		}
+		if err := sysfs.addPf(pf, simVfs, "0x154c", "iavf", simIommuGroup+len(pci)+1); err != nil {
+			return nil, err
+		}
+	}
+	return &simBackend{sysfs: sysfs}, nil
+}
+
+func (simBackend) spawn(cmd string, timeout time.Duration) (session, error) {
+	return newSimSession(cmd), nil
+}
+
+// the simulator runs in the wrapper process
+func (simBackend) pid(filePrefix string) int {
+	return os.Getpid()
+}
+
+func (simBackend) probe(testpmdPath string) (string, string, error) {
+	return testpmdPath, simVersion, nil
+}
+
+func (s *simBackend) bus() *pciBus {
+	return s.sysfs.bus()
+}
+
+func (s *simBackend) close() error {
+	return s.sysfs.remove()
+}
+
+func (simBackend) hugepageSizeKB() uint64 {
+	return simHugepageSizeKB
+}
+
+func (simBackend) cpus() cpuset.CPUSet {
+	b := cpuset.NewBuilder()
+	for i := 0; i < simCpus; i++ {
+		b.Add(i)
+	}
+	return b.Result()
+}
+
+type simPort struct {
+	name string
+	// pci or vdev
+	bus     string
+	driver  string
+	mac     string
+	peerMac string
+	// port counters, cleared by "clear port stats"
+	rxPackets uint64
+	txPackets uint64
+	// forwarding counters, cleared by "clear fwd stats" and on start
+	fwdRxPackets uint64
+	fwdTxPackets uint64
+}
+
+// simSession answers commands synchronously, Send appends the output to the buffer Expect reads from
+type simSession struct {
+	mu      sync.Mutex
+	out     bytes.Buffer
+	closed  bool
+	ports   []*simPort
+	fwdMode string
+	running bool
+	// rxq, txq, rxd and txd, checked by "port start"
+	cfg          map[string]int
+	portsStopped bool
+	// forwarding lcores, set with "set corelist"
+	fwdLcores []int
+	// counters are advanced up to this time
+	lastUpdate time.Time
+}
+
+func newSimSession(cmd string) *simSession {
+	s := &simSession{fwdMode: "io", cfg: map[string]int{"rxq": 1, "txq": 1, "rxd": 512, "txd": 512}, lastUpdate: time.Now()}
+	for _, m := range simPortCfgRE.FindAllStringSubmatch(cmd, -1) {
+		s.cfg[m[1]], _ = strconv.Atoi(m[2])
+	}
+	// all lcores but the main one forward, the main lcore is the first unless told otherwise
+	if m := simLcoresRE.FindStringSubmatch(cmd); m != nil {
+		if lcores, err := cpuset.Parse(m[1]); err == nil {
+			main := lcores.ToSlice()[0]
+			if m := simMainRE.FindStringSubmatch(cmd); m != nil {
+				main, _ = strconv.Atoi(m[1])
+			}
+			s.fwdLcores = lcores.Difference(cpuset.NewCPUSet(main)).ToSlice()
+		}
+	}
+	// the pci ports come first, then the vdevs
+	for _, m := range simPortRE.FindAllStringSubmatch(cmd, -1) {
+		s.ports = append(s.ports, &simPort{name: m[1], bus: "pci", driver: "net_i40e"})
+	}
+	for _, m := range simVdevRE.FindAllStringSubmatch(cmd, -1) {
+		name := strings.SplitN(m[1], ",", 2)[0]
+		s.ports = append(s.ports, &simPort{name: name, bus: "vdev", driver: simVdevDriverRE.FindStringSubmatch(name)[1]})
+	}
+	for i, p := range s.ports {
+		p.mac = fmt.Sprintf("02:00:00:00:00:%02X", i)
+		p.peerMac = fmt.Sprintf("02:00:00:00:01:%02X", i)
+	}
+	fmt.Fprintf(&s.out, "EAL: RTE Version: 'DPDK %s'\nEAL: Detected %d lcore(s)\nEAL: simulated testpmd\nInteractive-mode selected\n", simVersion, simCpus)
+	for i, p := range s.ports {
+		fmt.Fprintf(&s.out, "Port %d: %s\n", i, p.mac)
+	}
+	s.out.WriteString("Checking link statuses...\nDone\ntestpmd> ")
+	return s
+}
+
+func (s *simSession) Send(in string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errors.New("simulated testpmd is closed")
+	}
+	for _, cmd := range strings.Split(strings.TrimRight(in, "\n"), "\n") {
+		// the pty echoes the command
+		s.out.WriteString(cmd + "\n")
+		if cmd = strings.TrimSpace(cmd); cmd != "" {
+			s.out.WriteString(s.handle(cmd))
+		}
+		s.out.WriteString("testpmd> ")
+	}
+	return nil
+}
+
+func (s *simSession) Expect(re *regexp.Regexp, timeout time.Duration) (string, []string, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		s.mu.Lock()
+		buf := s.out.String()
+		if loc := re.FindStringSubmatchIndex(buf); loc != nil {
+			s.out.Next(loc[1])
+			s.mu.Unlock()
+			var match []string
+			for i := 0; i < len(loc); i += 2 {
+				if loc[i] >= 0 {
+					match = append(match, buf[loc[i]:loc[i+1]])
+				} else {
+					match = append(match, "")
+				}
+			}
+			return buf[:loc[1]], match, nil
+		}
+		closed := s.closed
+		s.mu.Unlock()
+		if closed {
+			return buf, nil, errors.New("simulated testpmd is closed")
+		}
+		if time.Now().After(deadline) {
+			return buf, nil, expect.TimeoutError(timeout)
+		}
+		time.Sleep(simPollInterval)
+	}
+}
+
+func (s *simSession) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+// advance adds the packets forwarded since the last update to the counters
+func (s *simSession) advance() {
+	now := time.Now()
+	n := uint64(now.Sub(s.lastUpdate).Seconds() * simPps)
+	s.lastUpdate = now
+	if !s.running {
+		return
+	}
+	rx, tx := n, n
+	switch s.fwdMode {
+	case "rxonly":
+		tx = 0
+	case "txonly", "flowgen":
+		rx = 0
+	}
+	for _, p := range s.ports {
+		p.rxPackets += rx
+		p.txPackets += tx
+		p.fwdRxPackets += rx
+		p.fwdTxPackets += tx
+	}
+}
+
+func (s *simSession) handle(cmd string) string {
+	s.advance()
+	f := strings.Fields(cmd)
+	switch {
+	case cmd == "start":
+		if s.running {
+			return "Packet forwarding already started\n"
+		}
+		if s.portsStopped {
+			return "Not all ports were started\n"
+		}
+		for _, p := range s.ports {
+			p.fwdRxPackets, p.fwdTxPackets = 0, 0
+		}
+		s.running = true
+		return fmt.Sprintf("%s packet forwarding - ports=%d - cores=%d - streams=%d\n",
+			s.fwdMode, len(s.ports), len(s.ports), len(s.ports))
+	case cmd == "stop":
+		if !s.running {
+			return "Packet forwarding not started\n"
+		}
+		s.running = false
+		return "Telling cores to stop...\nWaiting for lcores to finish...\n" + s.fwdStats() + "Done.\n"
+	case cmd == "help config":
+		return "set fwd (" + strings.Join(simModes, "|") + ")\n    Set packet forwarding mode.\n\n"
+	case len(f) == 3 && f[0] == "set" && f[1] == "fwd":
+		if !containsString(simModes, f[2]) {
+			return "Bad arguments\n"
+		}
+		s.fwdMode = f[2]
+		return fmt.Sprintf("Set %s packet forwarding mode\n", f[2])
+	case len(f) == 4 && f[0] == "set" && f[1] == "eth-peer":
+		for i, p := range s.ports {
+			if f[2] == fmt.Sprint(i) {
+				p.peerMac = f[3]
+				return ""
+			}
+		}
+		return fmt.Sprintf("Invalid port %s\n", f[2])
+	case len(f) == 3 && f[0] == "set" && (f[1] == "txpkts" || f[1] == "burst"):
+		return ""
+	case len(f) == 3 && f[0] == "set" && f[1] == "corelist":
+		lcores, err := cpuset.Parse(f[2])
+		if err != nil {
+			return "Bad arguments\n"
+		}
+		// the list order is kept
+		s.fwdLcores = nil
+		for _, l := range strings.Split(f[2], ",") {
+			n, _ := strconv.Atoi(l)
+			s.fwdLcores = append(s.fwdLcores, n)
+		}
+		if len(s.fwdLcores) != lcores.Size() {
+			return "Bad arguments\n"
+		}
+		return ""
+	case cmd == "port stop all":
+		if s.running {
+			return "Please stop forwarding first\n"
+		}
+		s.portsStopped = true
+		return "Stopping ports...\nChecking link statuses...\nDone\n"
+	case cmd == "port start all":
+		return s.startPorts()
+	case len(f) == 5 && strings.Join(f[:3], " ") == "port config all":
+		if !s.portsStopped {
+			return "Please stop all ports first\n"
+		}
+		n, err := strconv.Atoi(f[4])
+		if _, ok := s.cfg[f[3]]; !ok || err != nil {
+			return "Bad arguments\n"
+		}
+		if (f[3] == "rxq" || f[3] == "txq") && (n < 1 || n > simMaxQueues) {
+			return fmt.Sprintf("Fail: input %s (%d) can't be greater than max queues (%d) of port 0\n", f[3], n, simMaxQueues)
+		}
+		s.cfg[f[3]] = n
+		return ""
+	case len(f) == 5 && strings.Join(f[:3], " ") == "port config mtu":
+		n, err := strconv.Atoi(f[4])
+		if err != nil {
+			return "Bad arguments\n"
+		}
+		for i := range s.ports {
+			if f[3] == fmt.Sprint(i) {
+				if n < 68 || n > simMaxMtu {
+					return "Set MTU failed. diag=-22\n"
+				}
+				return ""
+			}
+		}
+		return fmt.Sprintf("Invalid port %s\n", f[3])
+	case cmd == "show config fwd":
+		return s.fwdConfig()
+	case len(f) == 4 && strings.Join(f[:3], " ") == "show device info":
+		return s.deviceInfo(f[3])
+	case cmd == "show fwd stats all":
+		return s.fwdStats()
+	case cmd == "clear fwd stats all":
+		for _, p := range s.ports {
+			p.fwdRxPackets, p.fwdTxPackets = 0, 0
+		}
+		return "\n  NIC statistics for all ports cleared\n"
+	case cmd == "show port stats all":
+		return s.portStats()
+	case cmd == "clear port stats all":
+		for _, p := range s.ports {
+			p.rxPackets, p.txPackets = 0, 0
+		}
+		return ""
+	}
+	return "Bad arguments\n"
+}
+
+// startPorts sets up the queues with the rings configured, the ring sizes of an i40e port are
+// only checked then
+func (s *simSession) startPorts() string {
+	for _, r := range []string{"rxd", "txd"} {
+		if n := s.cfg[r]; n < simMinRing || n > simMaxRing || n%32 != 0 {
+			return fmt.Sprintf("Fail to configure port 0 %s queues\n", r[:2])
+		}
+	}
+	s.portsStopped = false
+	var b strings.Builder
+	for i, p := range s.ports {
+		fmt.Fprintf(&b, "Port %d: %s\n", i, p.mac)
+	}
+	b.WriteString("Checking link statuses...\nDone\n")
+	return b.String()
+}
+
+func (s *simSession) deviceInfo(name string) string {
+	var b strings.Builder
+	for i, p := range s.ports {
+		if name != "all" && name != p.name {
+			continue
+		}
+		fmt.Fprintf(&b, "\n********************* Infos for device %s *********************\n", p.name)
+		fmt.Fprintf(&b, "Bus name: %s\nDriver name: %s\nDevargs: \nConnect to socket: 0\n\n", p.bus, p.driver)
+		fmt.Fprintf(&b, "\tPort id: %d \n\tMAC address: %s\n\tDevice name: %s\n", i, p.mac, p.name)
+		fmt.Fprintf(&b, "\tDevice speed capability: 10 Gbps  \n")
+	}
+	return b.String()
+}
+
+// fwdConfig spreads the streams over the forwarding lcores like testpmd does: queue 0 of every
+// port first, then queue 1..., and port pairs forward to each other
+func (s *simSession) fwdConfig() string {
+	var b strings.Builder
+	queues := s.cfg["rxq"]
+	if s.cfg["txq"] > queues {
+		queues = s.cfg["txq"]
+	}
+	streams := len(s.ports) * queues
+	cores := len(s.fwdLcores)
+	if cores > streams {
+		cores = streams
+	}
+	fmt.Fprintf(&b, "%s packet forwarding - ports=%d - cores=%d - streams=%d - NUMA support enabled, MP allocation mode: native\n",
+		s.fwdMode, len(s.ports), cores, streams)
+	if cores == 0 {
+		return b.String()
+	}
+	perCore := streams / cores
+	for c := 0; c < cores; c++ {
+		n := perCore
+		if c == cores-1 {
+			n = streams - c*perCore
+		}
+		fmt.Fprintf(&b, "Logical Core %d (socket 0) forwards packets on %d streams:\n", s.fwdLcores[c], n)
+		for i := c * perCore; i < c*perCore+n; i++ {
+			rxp, q := i%len(s.ports), i/len(s.ports)
+			txp := rxp ^ 1
+			if txp >= len(s.ports) {
+				txp = rxp
+			}
+			fmt.Fprintf(&b, "  RX P=%d/Q=%d (socket 0) -> TX P=%d/Q=%d (socket 0) peer=%s\n", rxp, q, txp, q, s.ports[txp].peerMac)
+		}
+	}
+	return b.String()
+}
+
+func (s *simSession) fwdStats() string {
+	var b strings.Builder
+	var rx, tx uint64
+	for i, p := range s.ports {
+		fmt.Fprintf(&b, "\n  ---------------------- Forward statistics for port %d  ----------------------\n", i)
+		fmt.Fprintf(&b, "  RX-packets: %-14d RX-dropped: %-14d RX-total: %d\n", p.fwdRxPackets, 0, p.fwdRxPackets)
+		fmt.Fprintf(&b, "  TX-packets: %-14d TX-dropped: %-14d TX-total: %d\n", p.fwdTxPackets, 0, p.fwdTxPackets)
+		fmt.Fprintf(&b, "  ----------------------------------------------------------------------------\n")
+		rx += p.fwdRxPackets
+		tx += p.fwdTxPackets
+	}
+	fmt.Fprintf(&b, "\n  +++++++++++++++ Accumulated forward statistics for all ports+++++++++++++++\n")
+	fmt.Fprintf(&b, "  RX-packets: %-14d RX-dropped: %-14d RX-total: %d\n", rx, 0, rx)
+	fmt.Fprintf(&b, "  TX-packets: %-14d TX-dropped: %-14d TX-total: %d\n", tx, 0, tx)
+	fmt.Fprintf(&b, "  ++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++\n")
+	return b.String()
+}
+
+func (s *simSession) portStats() string {
+	var b strings.Builder
+	for i, p := range s.ports {
+		fmt.Fprintf(&b, "\n  ######################## NIC statistics for port %d  ########################\n", i)
+		fmt.Fprintf(&b, "  RX-packets: %-10d RX-missed: %-10d RX-bytes:  %d\n", p.rxPackets, 0, p.rxPackets*simPktLen)
+		fmt.Fprintf(&b, "  RX-errors: %d\n  RX-nombuf:  %-10d\n", 0, 0)
+		fmt.Fprintf(&b, "  TX-packets: %-10d TX-errors: %-10d TX-bytes:  %d\n", p.txPackets, 0, p.txPackets*simPktLen)
+		fmt.Fprintf(&b, "\n  Throughput (since last show)\n  Rx-pps: %12d\n  Tx-pps: %12d\n", 0, 0)
+		fmt.Fprintf(&b, "  ############################################################################\n")
+	}
+	return b.String()
+}
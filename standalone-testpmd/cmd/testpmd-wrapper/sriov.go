@@ -0,0 +1,372 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpuset"
+)
+
+// vfLinker configures the VFs of a PF through the net device of the PF
+type vfLinker interface {
+	getVfs(pf string) ([]vfSettings, error)
+	setVfMac(pf string, vf int, mac net.HardwareAddr) error
+	setVfVlan(pf string, vf int, vlan int) error
+	setVfTrust(pf string, vf int, on bool) error
+	setVfSpoofchk(pf string, vf int, on bool) error
+}
+
+// vfSettings are the settings of a VF made through its PF, journaled to put them back
+type vfSettings struct {
+	Vf       int    `json:"vf"`
+	Mac      string `json:"mac"`
+	Vlan     int    `json:"vlan"`
+	Trust    bool   `json:"trust"`
+	Spoofchk bool   `json:"spoofchk"`
+}
+
+// netlinkVfLinker configures the VFs of the host with netlink
+type netlinkVfLinker struct{}
+
+// getVfs reads the VF info list of the PF, netlink.LinkAttrs has no trust setting
+func (netlinkVfLinker) getVfs(pf string) ([]vfSettings, error) {
+	link, err := netlink.LinkByName(pf)
+	if err != nil {
+		return nil, err
+	}
+	req := nl.NewNetlinkRequest(unix.RTM_GETLINK, unix.NLM_F_ACK)
+	msg := nl.NewIfInfomsg(unix.AF_UNSPEC)
+	msg.Index = int32(link.Attrs().Index)
+	req.AddData(msg)
+	req.AddData(nl.NewRtAttr(unix.IFLA_EXT_MASK, nl.Uint32Attr(nl.RTEXT_FILTER_VF)))
+	msgs, err := req.Execute(unix.NETLINK_ROUTE, unix.RTM_NEWLINK)
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("no link info for %s", pf)
+	}
+	attrs, err := nl.ParseRouteAttr(msgs[0][unix.SizeofIfInfomsg:])
+	if err != nil {
+		return nil, err
+	}
+	var vfs []vfSettings
+	for _, a := range attrs {
+		if a.Attr.Type != unix.IFLA_VFINFO_LIST {
+			continue
+		}
+		infos, err := nl.ParseRouteAttr(a.Value)
+		if err != nil {
+			return nil, err
+		}
+		for i, info := range infos {
+			fields, err := nl.ParseRouteAttr(info.Value)
+			if err != nil {
+				return nil, err
+			}
+			vf := vfSettings{Vf: i}
+			for _, f := range fields {
+				switch f.Attr.Type {
+				case nl.IFLA_VF_MAC:
+					vf.Mac = net.HardwareAddr(nl.DeserializeVfMac(f.Value).Mac[:6]).String()
+				case nl.IFLA_VF_VLAN:
+					vf.Vlan = int(nl.DeserializeVfVlan(f.Value).Vlan)
+				case nl.IFLA_VF_SPOOFCHK:
+					vf.Spoofchk = nl.DeserializeVfSpoofchk(f.Value).Setting != 0
+				case nl.IFLA_VF_TRUST:
+					vf.Trust = nl.DeserializeVfTrust(f.Value).Setting != 0
+				}
+			}
+			vfs = append(vfs, vf)
+		}
+	}
+	return vfs, nil
+}
+
+func (netlinkVfLinker) setVfMac(pf string, vf int, mac net.HardwareAddr) error {
+	link, err := netlink.LinkByName(pf)
+	if err != nil {
+		return err
+	}
+	return netlink.LinkSetVfHardwareAddr(link, vf, mac)
+}
+
+func (netlinkVfLinker) setVfVlan(pf string, vf int, vlan int) error {
+	link, err := netlink.LinkByName(pf)
+	if err != nil {
+		return err
+	}
+	return netlink.LinkSetVfVlan(link, vf, vlan)
+}
+
+func (netlinkVfLinker) setVfTrust(pf string, vf int, on bool) error {
+	link, err := netlink.LinkByName(pf)
+	if err != nil {
+		return err
+	}
+	return netlink.LinkSetVfTrust(link, vf, on)
+}
+
+func (netlinkVfLinker) setVfSpoofchk(pf string, vf int, on bool) error {
+	link, err := netlink.LinkByName(pf)
+	if err != nil {
+		return err
+	}
+	return netlink.LinkSetVfSpoofchk(link, vf, on)
+}
+
+// sriovConfig tells which VFs to create on a PF and how to set them up
+type sriovConfig struct {
+	pf     string
+	numVfs int
+	// VFs handed to testpmd, all of them if empty
+	vfs      cpuset.CPUSet
+	macs     map[int]net.HardwareAddr
+	vlans    map[int]int
+	trust    bool
+	spoofchk bool
+	// remove the VFs the PF already has if their number differs
+	reset bool
+}
+
+// sriovState is what setupSriov changed, for restoreSriov
+type sriovState struct {
+	pf     string
+	numVfs int
+	// the settings of the VFs the PF had that were set up again or replaced
+	vfs []vfSettings
+}
+
+func (b *pciBus) numVfs(pf string) (int, error) {
+	out, err := ioutil.ReadFile(filepath.Join(b.deviceDir(pf), "sriov_numvfs"))
+	if err != nil {
+		return 0, fmt.Errorf("%s is not an SR-IOV PF: %v", pf, err)
+	}
+	return strconv.Atoi(strings.TrimSpace(string(out)))
+}
+
+// setNumVfs sets sriov_numvfs and waits for the VF devices. A PF that already has another
+// number of VFs is refused unless replace is set, then it is set to 0 first as the kernel
+// doesn't change the count otherwise.
+func (b *pciBus) setNumVfs(pf string, n int, replace bool) error {
+	cur, err := b.numVfs(pf)
+	if err != nil {
+		return err
+	}
+	if cur == n {
+		return nil
+	}
+	path := filepath.Join(b.deviceDir(pf), "sriov_numvfs")
+	if cur != 0 && n != 0 {
+		if !replace {
+			return fmt.Errorf("%s already has %d VFs, other workloads may use them; "+
+				"ask for %d VFs to use them or pass -reset-vfs to replace them", pf, cur, cur)
+		}
+		log.Printf("setNumVfs: WARNING replacing the %d VFs of %s, echo 0 > %s", cur, pf, path)
+		if err := b.writer.writeFile(path, []byte("0")); err != nil {
+			return err
+		}
+	}
+	log.Printf("setNumVfs: echo %d > %s", n, path)
+	if err := b.writer.writeFile(path, []byte(strconv.Itoa(n))); err != nil {
+		return err
+	}
+	// the last VF shows up, or the first one goes away
+	last := filepath.Join(b.deviceDir(pf), fmt.Sprintf("virtfn%d", n-1))
+	if n == 0 {
+		last = filepath.Join(b.deviceDir(pf), "virtfn0")
+	}
+	deadline := time.Now().Add(bindTimeout)
+	for {
+		if _, err := os.Lstat(last); (err == nil) == (n > 0) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%s doesn't have %d VFs after %v", pf, n, bindTimeout)
+		}
+		time.Sleep(bindPollInterval)
+	}
+}
+
+// vfPci returns the pci address of a VF
+func (b *pciBus) vfPci(pf string, vf int) (string, error) {
+	target, err := os.Readlink(filepath.Join(b.deviceDir(pf), fmt.Sprintf("virtfn%d", vf)))
+	if err != nil {
+		return "", fmt.Errorf("no VF %d on %s: %v", vf, pf, err)
+	}
+	return filepath.Base(target), nil
+}
+
+// setupSriov creates the VFs of the PF, sets them up through the PF and returns the addresses of
+// the VFs for testpmd, to be bound like the other ports
+func (b *pciBus) setupSriov(c *sriovConfig) (pciArray, *sriovState, error) {
+	pfName, err := b.netDevice(c.pf)
+	if err != nil {
+		return nil, nil, err
+	}
+	prev, err := b.numVfs(c.pf)
+	if err != nil {
+		return nil, nil, err
+	}
+	total, _ := ioutil.ReadFile(filepath.Join(b.deviceDir(c.pf), "sriov_totalvfs"))
+	if max, err := strconv.Atoi(strings.TrimSpace(string(total))); err == nil && c.numVfs > max {
+		return nil, nil, fmt.Errorf("%s supports %d VFs, %d asked", c.pf, max, c.numVfs)
+	}
+	vfs := c.vfs.ToSlice()
+	if c.vfs.IsEmpty() {
+		for i := 0; i < c.numVfs; i++ {
+			vfs = append(vfs, i)
+		}
+	}
+	for _, vf := range vfs {
+		if vf >= c.numVfs {
+			return nil, nil, fmt.Errorf("VF %d out of range, %s gets %d VFs", vf, c.pf, c.numVfs)
+		}
+	}
+	state := &sriovState{pf: c.pf, numVfs: prev}
+	// the VFs that are there before change their settings, or go away with -reset-vfs
+	if prev == c.numVfs || c.reset {
+		reused := vfs
+		if prev != c.numVfs {
+			reused = nil
+			for i := 0; i < prev; i++ {
+				reused = append(reused, i)
+			}
+		}
+		if state.vfs, err = b.snapshotVfs(pfName, reused); err != nil {
+			return nil, nil, fmt.Errorf("failed to read the VFs of %s: %v", pfName, err)
+		}
+	}
+	if prev != c.numVfs || len(state.vfs) > 0 {
+		// journal the count and settings before changing them, for the next start if the
+		// wrapper is killed
+		b.sriov = state
+		if err := b.saveJournal(nil, nil); err != nil {
+			b.sriov = nil
+			return nil, nil, fmt.Errorf("failed to write journal: %v", err)
+		}
+	}
+	if err := b.setNumVfs(c.pf, c.numVfs, c.reset); err != nil {
+		b.restoreSriov(state)
+		return nil, nil, err
+	}
+	var pci pciArray
+	for _, vf := range vfs {
+		if err := b.setupVf(pfName, vf, c); err != nil {
+			b.restoreSriov(state)
+			return nil, nil, fmt.Errorf("failed to set up VF %d of %s: %v", vf, pfName, err)
+		}
+		p, err := b.vfPci(c.pf, vf)
+		if err != nil {
+			b.restoreSriov(state)
+			return nil, nil, err
+		}
+		pci = append(pci, p)
+	}
+	log.Printf("setupSriov: %d VFs on %s (%s), using %+q", c.numVfs, c.pf, pfName, pci)
+	return pci, state, nil
+}
+
+func (b *pciBus) setupVf(pfName string, vf int, c *sriovConfig) error {
+	if mac, ok := c.macs[vf]; ok {
+		log.Printf("setupVf: ip link set %s vf %d mac %s", pfName, vf, mac)
+		if err := b.netlink.setVfMac(pfName, vf, mac); err != nil {
+			return err
+		}
+	}
+	if vlan, ok := c.vlans[vf]; ok {
+		log.Printf("setupVf: ip link set %s vf %d vlan %d", pfName, vf, vlan)
+		if err := b.netlink.setVfVlan(pfName, vf, vlan); err != nil {
+			return err
+		}
+	}
+	log.Printf("setupVf: ip link set %s vf %d trust %s spoofchk %s", pfName, vf, onOff(c.trust), onOff(c.spoofchk))
+	if err := b.netlink.setVfTrust(pfName, vf, c.trust); err != nil {
+		return err
+	}
+	return b.netlink.setVfSpoofchk(pfName, vf, c.spoofchk)
+}
+
+// snapshotVfs returns the settings of the given VFs
+func (b *pciBus) snapshotVfs(pfName string, vfs []int) ([]vfSettings, error) {
+	if len(vfs) == 0 {
+		return nil, nil
+	}
+	all, err := b.netlink.getVfs(pfName)
+	if err != nil {
+		return nil, err
+	}
+	var saved []vfSettings
+	for _, vf := range vfs {
+		if vf >= len(all) {
+			return nil, fmt.Errorf("%s reports %d VFs, no VF %d", pfName, len(all), vf)
+		}
+		saved = append(saved, all[vf])
+	}
+	return saved, nil
+}
+
+// restoreVf puts back the settings of a VF
+func (b *pciBus) restoreVf(pfName string, s vfSettings) error {
+	mac, err := net.ParseMAC(s.Mac)
+	if err != nil {
+		return err
+	}
+	log.Printf("restoreVf: ip link set %s vf %d mac %s vlan %d trust %s spoofchk %s",
+		pfName, s.Vf, s.Mac, s.Vlan, onOff(s.Trust), onOff(s.Spoofchk))
+	if err := b.netlink.setVfMac(pfName, s.Vf, mac); err != nil {
+		return err
+	}
+	if err := b.netlink.setVfVlan(pfName, s.Vf, s.Vlan); err != nil {
+		return err
+	}
+	if err := b.netlink.setVfTrust(pfName, s.Vf, s.Trust); err != nil {
+		return err
+	}
+	return b.netlink.setVfSpoofchk(pfName, s.Vf, s.Spoofchk)
+}
+
+// restoreSriov puts the PF back to the VF count it had, removing the VFs it created, and the VFs
+// it had back to their settings. A VF that fails doesn't stop the others, the journal is kept
+// until all of them are back.
+func (b *pciBus) restoreSriov(state *sriovState) error {
+	log.Printf("restoreSriov: %s back to %d VFs", state.pf, state.numVfs)
+	if err := b.setNumVfs(state.pf, state.numVfs, true); err != nil {
+		return err
+	}
+	if len(state.vfs) > 0 {
+		pfName, err := b.netDevice(state.pf)
+		if err != nil {
+			return err
+		}
+		var failed []string
+		for _, s := range state.vfs {
+			if err := b.restoreVf(pfName, s); err != nil {
+				failed = append(failed, fmt.Sprintf("VF %d of %s: %v", s.Vf, pfName, err))
+			}
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("%s", strings.Join(failed, ", "))
+		}
+	}
+	b.sriov = nil
+	b.removeJournal()
+	return nil
+}
+
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpuset"
+)
+
+const testPf = "0000:87:00.0"
+
+// newSriovSysfs returns a fake sysfs with an X710 PF on i40e that already has numVfs VFs on iavf,
+// set up by another user
+func newSriovSysfs(t *testing.T, numVfs int) *fakeSysfs {
+	t.Helper()
+	f, err := newFakeSysfs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range []string{"i40e", "iavf"} {
+		if err := f.addDriver(d, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.addDevice(testPf, "0x8086", "0x1572", 0, "i40e", "ens2f0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.addPf(testPf, 8, "0x154c", "iavf", simIommuGroup); err != nil {
+		t.Fatal(err)
+	}
+	if numVfs > 0 {
+		if err := f.bus().setNumVfs(testPf, numVfs, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for vf := 0; vf < numVfs; vf++ {
+		mac, _ := net.ParseMAC(fmt.Sprintf("02:00:00:00:04:%02x", vf))
+		if err := f.setVfMac("ens2f0", vf, mac); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.setVfVlan("ens2f0", vf, 10+vf); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.setVfTrust("ens2f0", vf, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f.vfLinkCmds = nil
+	return f
+}
+
+// checkVfSettings fails unless the VFs of the PF have the given settings
+func checkVfSettings(t *testing.T, f *fakeSysfs, want []vfSettings) {
+	t.Helper()
+	vfs, err := f.getVfs("ens2f0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(vfs, want) {
+		t.Errorf("VF settings %+v, want %+v", vfs, want)
+	}
+}
+
+func checkNumVfs(t *testing.T, b *pciBus, want int) {
+	t.Helper()
+	n, err := b.numVfs(testPf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != want {
+		t.Errorf("PF has %d VFs, want %d", n, want)
+	}
+	for vf := 0; vf < 8; vf++ {
+		_, err := os.Stat(b.deviceDir(vfAddress(testPf, vf)))
+		if exists := err == nil; exists != (vf < want) {
+			t.Errorf("VF %d device exists: %v, with %d VFs", vf, exists, want)
+		}
+	}
+}
+
+func TestSetupSriov(t *testing.T) {
+	mac, _ := net.ParseMAC("02:00:00:00:03:00")
+	tests := []struct {
+		name string
+		// VFs the PF has before
+		prev int
+		c    sriovConfig
+		// VF devices handed to testpmd, nil on error
+		want []int
+		// VF settings applied
+		wantCmds []string
+		// part of the error, none if empty
+		wantErr string
+	}{
+		{
+			name: "all VFs",
+			c:    sriovConfig{numVfs: 2, spoofchk: true},
+			want: []int{0, 1},
+			wantCmds: []string{
+				"ip link set ens2f0 vf 0 trust off", "ip link set ens2f0 vf 0 spoofchk on",
+				"ip link set ens2f0 vf 1 trust off", "ip link set ens2f0 vf 1 spoofchk on",
+			},
+		},
+		{
+			name: "some VFs with mac and vlan",
+			c: sriovConfig{numVfs: 4, vfs: cpuset.NewCPUSet(1, 3), macs: map[int]net.HardwareAddr{1: mac},
+				vlans: map[int]int{3: 100}, trust: true},
+			want: []int{1, 3},
+			wantCmds: []string{
+				"ip link set ens2f0 vf 1 mac 02:00:00:00:03:00", "ip link set ens2f0 vf 1 trust on",
+				"ip link set ens2f0 vf 1 spoofchk off", "ip link set ens2f0 vf 3 vlan 100",
+				"ip link set ens2f0 vf 3 trust on", "ip link set ens2f0 vf 3 spoofchk off",
+			},
+		},
+		{
+			name: "same count as before",
+			prev: 2,
+			c:    sriovConfig{numVfs: 2, vfs: cpuset.NewCPUSet(1)},
+			want: []int{1},
+			wantCmds: []string{
+				"ip link set ens2f0 vf 1 trust off", "ip link set ens2f0 vf 1 spoofchk off",
+			},
+		},
+		{
+			name:    "other count without reset",
+			prev:    2,
+			c:       sriovConfig{numVfs: 4},
+			wantErr: "already has 2 VFs",
+		},
+		{
+			name: "other count with reset",
+			prev: 2,
+			c:    sriovConfig{numVfs: 3, vfs: cpuset.NewCPUSet(2), reset: true},
+			want: []int{2},
+			wantCmds: []string{
+				"ip link set ens2f0 vf 2 trust off", "ip link set ens2f0 vf 2 spoofchk off",
+			},
+		},
+		{
+			name:    "more than the PF supports",
+			c:       sriovConfig{numVfs: 9},
+			wantErr: "supports 8 VFs",
+		},
+		{
+			name:    "VF out of range",
+			c:       sriovConfig{numVfs: 2, vfs: cpuset.NewCPUSet(2)},
+			wantErr: "VF 2 out of range",
+		},
+		{
+			// the VFs are created, then removed again when a VF can't be set up
+			name:    "failed VF setup",
+			c:       sriovConfig{numVfs: 2, vlans: map[int]int{1: 5000}},
+			wantErr: "failed to set up VF 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newSriovSysfs(t, tt.prev)
+			defer f.remove()
+			before, err := f.getVfs("ens2f0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			b := f.bus()
+			c := tt.c
+			c.pf = testPf
+			pci, state, err := b.setupSriov(&c)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error %v, want %q", err, tt.wantErr)
+				}
+				checkNumVfs(t, b, tt.prev)
+				checkVfSettings(t, f, before)
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var want pciArray
+			for _, vf := range tt.want {
+				want = append(want, vfAddress(testPf, vf))
+			}
+			if !reflect.DeepEqual(pci, want) {
+				t.Errorf("VFs %q, want %q", pci, want)
+			}
+			if !reflect.DeepEqual(f.vfLinkCmds, tt.wantCmds) {
+				t.Errorf("VF settings %q, want %q", f.vfLinkCmds, tt.wantCmds)
+			}
+			checkNumVfs(t, b, c.numVfs)
+			if err := b.restoreSriov(state); err != nil {
+				t.Fatal(err)
+			}
+			checkNumVfs(t, b, tt.prev)
+			checkVfSettings(t, f, before)
+		})
+	}
+}
+
+func TestSriovJournal(t *testing.T) {
+	f := newSriovSysfs(t, 0)
+	defer f.remove()
+	dir, err := ioutil.TempDir("", "journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	journal := &bindingJournal{path: filepath.Join(dir, "bindings.json")}
+	b := f.bus()
+	b.journal = journal
+	if _, _, err := b.setupSriov(&sriovConfig{pf: testPf, numVfs: 4}); err != nil {
+		t.Fatal(err)
+	}
+	_, _, _, sriov, err := journal.load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (&sriovState{pf: testPf, numVfs: 0}); !reflect.DeepEqual(sriov, want) {
+		t.Fatalf("journaled VFs %+v, want %+v", sriov, want)
+	}
+
+	// a wrapper that was killed leaves the VFs, the next one removes them
+	next := f.bus()
+	next.journal = journal
+	if err := next.restoreFromJournal(); err != nil {
+		t.Fatal(err)
+	}
+	checkNumVfs(t, next, 0)
+	if _, err := os.Stat(journal.path); !os.IsNotExist(err) {
+		t.Errorf("journal not removed: %v", err)
+	}
+}
+
+// the VFs of another user keep their settings when the wrapper that took them over is killed
+func TestSriovJournalSettings(t *testing.T) {
+	f := newSriovSysfs(t, 2)
+	defer f.remove()
+	before, err := f.getVfs("ens2f0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	journal := &bindingJournal{path: filepath.Join(dir, "bindings.json")}
+	b := f.bus()
+	b.journal = journal
+	mac, _ := net.ParseMAC("02:00:00:00:03:00")
+	c := &sriovConfig{pf: testPf, numVfs: 2, vfs: cpuset.NewCPUSet(1), macs: map[int]net.HardwareAddr{1: mac}, spoofchk: true}
+	if _, _, err := b.setupSriov(c); err != nil {
+		t.Fatal(err)
+	}
+	_, _, _, sriov, err := journal.load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (&sriovState{pf: testPf, numVfs: 2, vfs: before[1:]}); !reflect.DeepEqual(sriov, want) {
+		t.Fatalf("journaled VFs %+v, want %+v", sriov, want)
+	}
+
+	next := f.bus()
+	next.journal = journal
+	if err := next.restoreFromJournal(); err != nil {
+		t.Fatal(err)
+	}
+	checkNumVfs(t, next, 2)
+	checkVfSettings(t, f, before)
+	if _, err := os.Stat(journal.path); !os.IsNotExist(err) {
+		t.Errorf("journal not removed: %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/redhat-nfvpe/container-perf-tools/standalone-testpmd/rpc"
+)
+
+var (
+	fwdPortHeaderRE  = regexp.MustCompile(`Forward statistics for port\s+(\d+)`)
+	fwdAccumHeaderRE = regexp.MustCompile(`Accumulated forward statistics for all ports`)
+	// per stream stats, printed when there are more streams than ports
+	fwdStreamHeaderRE = regexp.MustCompile(`Forward Stats for RX Port`)
+	nicStatsHeaderRE  = regexp.MustCompile(`NIC statistics for port\s+(\d+)`)
+	counterRE         = regexp.MustCompile(`([A-Za-z][A-Za-z0-9-]*):\s*(\d+)`)
+)
+
+// port counters from "show port stats"
+type portCounters struct {
+	portNum   int32
+	rxPackets uint64
+	rxMissed  uint64
+	rxBytes   uint64
+	rxErrors  uint64
+	rxNombuf  uint64
+	txPackets uint64
+	txErrors  uint64
+	txBytes   uint64
+}
+
+// isSectionEnd returns true for the "-----", "+++++" and "#####" lines closing a stats block
+func isSectionEnd(line string) bool {
+	line = strings.TrimSpace(line)
+	return len(line) > 0 && strings.Trim(line, "-+#") == ""
+}
+
+func setFwdCounter(stats *pb.PortFwdStats, name string, value uint64) {
+	switch name {
+	case "RX-packets":
+		stats.RxPackets = value
+	case "RX-dropped":
+		stats.RxDropped = value
+	case "RX-total":
+		stats.RxTotal = value
+	case "TX-packets":
+		stats.TxPackets = value
+	case "TX-dropped":
+		stats.TxDropped = value
+	case "TX-total":
+		stats.TxTotal = value
+	case "Bad-ipcsum":
+		stats.RxBadIpCsum = value
+	case "Bad-l4csum":
+		stats.RxBadL4Csum = value
+	case "Bad-outer-l4csum":
+		stats.RxBadOuterL4Csum = value
+	}
+}
+
+// parseFwdStats parses the output of "show fwd stats all"; the same blocks are printed by "stop"
+func parseFwdStats(output string) (*pb.FwdStats, error) {
+	stats := &pb.FwdStats{}
+	var cur *pb.PortFwdStats
+	for _, line := range strings.Split(output, "\n") {
+		if m := fwdPortHeaderRE.FindStringSubmatch(line); m != nil {
+			num, err := strconv.Atoi(m[1])
+			if err != nil {
+				return nil, err
+			}
+			cur = &pb.PortFwdStats{PortNum: int32(num)}
+			stats.PortStats = append(stats.PortStats, cur)
+			continue
+		}
+		if fwdAccumHeaderRE.MatchString(line) {
+			cur = &pb.PortFwdStats{PortNum: -1}
+			stats.Accumulated = cur
+			continue
+		}
+		if fwdStreamHeaderRE.MatchString(line) || isSectionEnd(line) {
+			cur = nil
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+		for _, m := range counterRE.FindAllStringSubmatch(line, -1) {
+			value, err := strconv.ParseUint(m[2], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			setFwdCounter(cur, m[1], value)
+		}
+	}
+	if len(stats.PortStats) == 0 {
+		return nil, fmt.Errorf("no forward statistics found in testpmd output")
+	}
+	return stats, nil
+}
+
+func setPortCounter(c *portCounters, name string, value uint64) {
+	switch name {
+	case "RX-packets":
+		c.rxPackets = value
+	case "RX-missed":
+		c.rxMissed = value
+	case "RX-bytes":
+		c.rxBytes = value
+	case "RX-errors":
+		c.rxErrors = value
+	case "RX-nombuf":
+		c.rxNombuf = value
+	case "TX-packets":
+		c.txPackets = value
+	case "TX-errors":
+		c.txErrors = value
+	case "TX-bytes":
+		c.txBytes = value
+	}
+}
+
+// parsePortStats parses the output of "show port stats all"
+func parsePortStats(output string) ([]*portCounters, error) {
+	var ports []*portCounters
+	var cur *portCounters
+	for _, line := range strings.Split(output, "\n") {
+		if m := nicStatsHeaderRE.FindStringSubmatch(line); m != nil {
+			num, err := strconv.Atoi(m[1])
+			if err != nil {
+				return nil, err
+			}
+			cur = &portCounters{portNum: int32(num)}
+			ports = append(ports, cur)
+			continue
+		}
+		if isSectionEnd(line) {
+			cur = nil
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+		for _, m := range counterRE.FindAllStringSubmatch(line, -1) {
+			value, err := strconv.ParseUint(m[2], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			setPortCounter(cur, m[1], value)
+		}
+	}
+	if len(ports) == 0 {
+		return nil, fmt.Errorf("no port statistics found in testpmd output")
+	}
+	return ports, nil
+}
+
+// counterDelta tolerates counters being cleared between two samples
+func counterDelta(cur uint64, prev uint64) uint64 {
+	if cur < prev {
+		return cur
+	}
+	return cur - prev
+}
+
+// portThroughput computes the rates between two samples of the same port
+func portThroughput(cur *portCounters, prev *portCounters, elapsed time.Duration) *pb.PortThroughput {
+	secs := elapsed.Seconds()
+	rate := func(c uint64, p uint64) float64 {
+		return float64(counterDelta(c, p)) / secs
+	}
+	return &pb.PortThroughput{
+		PortNum:    cur.portNum,
+		RxPps:      rate(cur.rxPackets, prev.rxPackets),
+		TxPps:      rate(cur.txPackets, prev.txPackets),
+		RxBps:      rate(cur.rxBytes, prev.rxBytes) * 8,
+		TxBps:      rate(cur.txBytes, prev.txBytes) * 8,
+		RxDropPps:  rate(cur.rxMissed+cur.rxErrors+cur.rxNombuf, prev.rxMissed+prev.rxErrors+prev.rxNombuf),
+		TxErrorPps: rate(cur.txErrors, prev.txErrors),
+	}
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/redhat-nfvpe/container-perf-tools/standalone-testpmd/rpc"
+)
+
+// "stop" of testpmd 18.11 in csum mode with 2 queues, the stream blocks come first and have
+// no closing line. A 14 digit counter runs into the name of the next one.
+const stop1811 = `Telling cores to stop...
+Waiting for lcores to finish...
+
+  ------- Forward Stats for RX Port= 0/Queue= 0 -> TX Port= 1/Queue= 0 -------
+  RX-packets: 600            TX-packets: 600            TX-dropped: 0
+  RX- bad IP checksum: 1               Rx- bad L4 checksum: 2               Rx- bad outer L4 checksum: 0
+
+  ------- Forward Stats for RX Port= 0/Queue= 1 -> TX Port= 1/Queue= 1 -------
+  RX-packets: 400            TX-packets: 400            TX-dropped: 0
+  RX- bad IP checksum: 0               Rx- bad L4 checksum: 1               Rx- bad outer L4 checksum: 0
+
+  ---------------------- Forward statistics for port 0  ----------------------
+  RX-packets: 1000           RX-dropped: 12345678901234RX-total: 12345678902234
+  Bad-ipcsum: 1              Bad-l4csum: 3              Bad-outer-l4csum: 0
+  RX-error: 7
+  RX-nombufs: 2
+  TX-packets: 0              TX-dropped: 0             TX-total: 0
+  ----------------------------------------------------------------------------
+
+  ---------------------- Forward statistics for port 1  ----------------------
+  RX-packets: 0              RX-dropped: 0             RX-total: 0
+  Bad-ipcsum: 0              Bad-l4csum: 0              Bad-outer-l4csum: 0
+  TX-packets: 1000           TX-dropped: 0             TX-total: 1000
+  ----------------------------------------------------------------------------
+
+  +++++++++++++++ Accumulated forward statistics for all ports+++++++++++++++
+  RX-packets: 1000           RX-dropped: 12345678901234RX-total: 12345678902234
+  TX-packets: 1000           TX-dropped: 0             TX-total: 1000
+  ++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++
+
+Done.
+testpmd> `
+
+// "show fwd stats all" of testpmd 19.11 in io mode, one stream per port
+const showFwdStats1911 = `
+  ---------------------- Forward statistics for port 0  ----------------------
+  RX-packets: 3214           RX-dropped: 5             RX-total: 3219
+  TX-packets: 3100           TX-dropped: 114           TX-total: 3214
+  ----------------------------------------------------------------------------
+
+  ---------------------- Forward statistics for port 1  ----------------------
+  RX-packets: 3100           RX-dropped: 0             RX-total: 3100
+  TX-packets: 3214           TX-dropped: 0             TX-total: 3214
+  ----------------------------------------------------------------------------
+
+  +++++++++++++++ Accumulated forward statistics for all ports+++++++++++++++
+  RX-packets: 6314           RX-dropped: 5             RX-total: 6319
+  TX-packets: 6314           TX-dropped: 114           TX-total: 6428
+  ++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++
+testpmd> `
+
+// "stop" of testpmd 21.11 in csum mode with 2 queues on one port, with the
+// Bad-outer-ipcsum counter of 21.x and the stream blocks between the port blocks
+const stop2111 = `Telling cores to stop...
+Waiting for lcores to finish...
+
+  ------- Forward Stats for RX Port= 0/Queue= 0 -> TX Port= 0/Queue= 0 -------
+  RX-packets: 50             TX-packets: 50             TX-dropped: 0
+  RX- bad IP checksum: 0               Rx- bad L4 checksum: 4               Rx- bad outer L4 checksum: 1
+  RX- bad outer IP checksum: 0
+
+  ------- Forward Stats for RX Port= 0/Queue= 1 -> TX Port= 0/Queue= 1 -------
+  RX-packets: 70             TX-packets: 70             TX-dropped: 0
+  RX- bad IP checksum: 2               Rx- bad L4 checksum: 0               Rx- bad outer L4 checksum: 0
+  RX- bad outer IP checksum: 0
+
+  ---------------------- Forward statistics for port 0  ----------------------
+  RX-packets: 120            RX-dropped: 0             RX-total: 120
+  Bad-ipcsum: 2              Bad-l4csum: 4              Bad-outer-l4csum: 1
+  Bad-outer-ipcsum: 0
+  TX-packets: 120            TX-dropped: 0             TX-total: 120
+  ----------------------------------------------------------------------------
+
+  +++++++++++++++ Accumulated forward statistics for all ports+++++++++++++++
+  RX-packets: 120            RX-dropped: 0             RX-total: 120
+  TX-packets: 120            TX-dropped: 0             TX-total: 120
+  ++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++
+
+Done.
+testpmd> `
+
+func TestParseFwdStats(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   *pb.FwdStats
+	}{
+		{
+			name:   "18.11 stop csum",
+			output: stop1811,
+			want: &pb.FwdStats{
+				PortStats: []*pb.PortFwdStats{
+					{PortNum: 0, RxPackets: 1000, RxDropped: 12345678901234, RxTotal: 12345678902234, RxBadIpCsum: 1, RxBadL4Csum: 3},
+					{PortNum: 1, TxPackets: 1000, TxTotal: 1000},
+				},
+				Accumulated: &pb.PortFwdStats{PortNum: -1, RxPackets: 1000, RxDropped: 12345678901234, RxTotal: 12345678902234,
+					TxPackets: 1000, TxTotal: 1000},
+			},
+		},
+		{
+			name:   "19.11 show fwd stats",
+			output: showFwdStats1911,
+			want: &pb.FwdStats{
+				PortStats: []*pb.PortFwdStats{
+					{PortNum: 0, RxPackets: 3214, RxDropped: 5, RxTotal: 3219, TxPackets: 3100, TxDropped: 114, TxTotal: 3214},
+					{PortNum: 1, RxPackets: 3100, RxTotal: 3100, TxPackets: 3214, TxTotal: 3214},
+				},
+				Accumulated: &pb.PortFwdStats{PortNum: -1, RxPackets: 6314, RxDropped: 5, RxTotal: 6319,
+					TxPackets: 6314, TxDropped: 114, TxTotal: 6428},
+			},
+		},
+		{
+			name:   "21.11 stop csum",
+			output: stop2111,
+			want: &pb.FwdStats{
+				PortStats: []*pb.PortFwdStats{
+					{PortNum: 0, RxPackets: 120, RxTotal: 120, TxPackets: 120, TxTotal: 120,
+						RxBadIpCsum: 2, RxBadL4Csum: 4, RxBadOuterL4Csum: 1},
+				},
+				Accumulated: &pb.PortFwdStats{PortNum: -1, RxPackets: 120, RxTotal: 120, TxPackets: 120, TxTotal: 120},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFwdStats(tt.output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("got %v\nwant %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFwdStatsNoPorts(t *testing.T) {
+	for _, output := range []string{"Packet forwarding not started\ntestpmd> ", "Bad arguments\n"} {
+		if stats, err := parseFwdStats(output); err == nil {
+			t.Errorf("%q parsed as %v", output, stats)
+		}
+	}
+}
+
+// "show port stats all" of testpmd 19.11 and later
+const showPortStats = `
+  ######################## NIC statistics for port 0  ########################
+  RX-packets: 1000       RX-missed: 5          RX-bytes:  64000
+  RX-errors: 2
+  RX-nombuf:  1
+  TX-packets: 990        TX-errors: 3          TX-bytes:  63360
+
+  Throughput (since last show)
+  Rx-pps:          120          Rx-bps:        61440
+  Tx-pps:          118          Tx-bps:        60416
+  ############################################################################
+
+  ######################## NIC statistics for port 1  ########################
+  RX-packets: 990        RX-missed: 0          RX-bytes:  63360
+  RX-errors: 0
+  RX-nombuf:  0
+  TX-packets: 1000       TX-errors: 0          TX-bytes:  64000
+
+  Throughput (since last show)
+  Rx-pps:          118          Rx-bps:        60416
+  Tx-pps:          120          Tx-bps:        61440
+  ############################################################################
+testpmd> `
+
+func TestParsePortStats(t *testing.T) {
+	got, err := parsePortStats(showPortStats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*portCounters{
+		{portNum: 0, rxPackets: 1000, rxMissed: 5, rxBytes: 64000, rxErrors: 2, rxNombuf: 1, txPackets: 990, txErrors: 3, txBytes: 63360},
+		{portNum: 1, rxPackets: 990, rxBytes: 63360, txPackets: 1000, txBytes: 64000},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+	if _, err := parsePortStats("Bad arguments\n"); err == nil {
+		t.Error("output without ports parsed")
+	}
+}
+
+func TestPortThroughput(t *testing.T) {
+	tests := []struct {
+		name      string
+		cur, prev portCounters
+		elapsed   time.Duration
+		want      *pb.PortThroughput
+	}{
+		{
+			name:    "rates",
+			prev:    portCounters{portNum: 1, rxPackets: 1000, rxBytes: 64000, txPackets: 900, txBytes: 57600, rxMissed: 1},
+			cur:     portCounters{portNum: 1, rxPackets: 3000, rxBytes: 192000, txPackets: 2900, txBytes: 185600, rxMissed: 5, rxErrors: 2, rxNombuf: 2, txErrors: 4},
+			elapsed: 2 * time.Second,
+			want:    &pb.PortThroughput{PortNum: 1, RxPps: 1000, TxPps: 1000, RxBps: 512000, TxBps: 512000, RxDropPps: 4, TxErrorPps: 2},
+		},
+		{
+			// the counters were cleared between the samples, the new values are the delta
+			name:    "cleared",
+			prev:    portCounters{rxPackets: 5000, rxBytes: 320000, txPackets: 5000, txBytes: 320000},
+			cur:     portCounters{rxPackets: 500, rxBytes: 32000, txPackets: 400, txBytes: 25600},
+			elapsed: 500 * time.Millisecond,
+			want:    &pb.PortThroughput{RxPps: 1000, TxPps: 800, RxBps: 512000, TxBps: 409600},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := portThroughput(&tt.cur, &tt.prev, tt.elapsed)
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("got %v\nwant %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,71 +1,113 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
-	"path/filepath"
-	"os"
 
-	expect "github.com/google/goexpect"
 	"github.com/lithammer/shortuuid"
+	pb "github.com/redhat-nfvpe/container-perf-tools/standalone-testpmd/rpc"
 )
 
 const (
 	startTimeout = 60 * time.Second
 	cmdTimeout   = 1 * time.Second
-	// page size in Mbyte per port
-	pageSizePerPort = 1024
 )
 
 var (
 	promptRE = regexp.MustCompile(`testpmd>`)
+	// "help config" lists the engines as "set fwd (io|mac|...)"
+	fwdModesRE = regexp.MustCompile(`set fwd \(([^)]+)\)`)
+	badArgsRE  = regexp.MustCompile(`Bad arguments|Invalid|Unknown|Fail[: ]| failed|Please stop`)
+	// "start" refusing to forward
+	startRejectedRE = regexp.MustCompile(`Not all ports were started|Packet forwarding already started`)
 )
 
 type testpmd struct {
 	fwdMode    string
 	running    bool
 	filePrefix string
-	e          *expect.GExpect
+	startTime  time.Time
+	pid        int
+	cmdline    string
+	// queues, rings and mtu of the ports, changed by configurePorts
+	portCfg  portSettings
+	mbufSize int
+	// startup options of the noisy engine, set before init
+	noisy noisySettings
+	// MB of memory per numa node
+	socketMem []int
+	cores     *corePlan
+	// the binary and its DPDK version, empty if unknown
+	testpmdPath string
+	dpdkVersion string
+	dpdk        *dpdkRelease
+	// forwarding modes supported by this testpmd, lazily filled
+	fwdModes []string
+	// pci addresses and vdev names, in port order
+	ports []string
+	b     backend
+	x     *cmdExecutor
+	// protects fwdMode and running for readers outside the grpc handlers
+	stateMu sync.Mutex
+	// serializes operations made of several commands, like a mode change
+	opMu sync.Mutex
 }
 
 var pTestpmd *testpmd
 
-func (t *testpmd) init(pci pciArray, queues int, ring int, testpmdPath string) error {
-	ports := len(pci)
+// vdev ports, like net_null0 or net_vhost0,iface=/var/run/vhost.sock
+type vdevArray []string
+
+func (t *testpmd) init(pci pciArray, vdevs vdevArray, ealArgs []string, queues int, ring int, mbufSize int, testpmdPath string) error {
+	t.ports = append([]string{}, pci...)
+	// testpmd names a vdev port without its devargs, net_af_packet0 for net_af_packet0,iface=veth0
+	for _, v := range vdevs {
+		t.ports = append(t.ports, strings.SplitN(v, ",", 2)[0])
+	}
+	ports := len(t.ports)
 	nPmd := ports * queues
-	// one extra core for mgmt in addition to the pmd
-	nCores := nPmd + 1
-	cset := getProcCpuset()
-	if nCores > cset.Size() {
-		log.Fatal("insufficient cores!")
+	if t.cores == nil {
+		if err := t.planCores(pci, vdevs, queues, corePolicyLocalPreferred, nil); err != nil {
+			return err
+		}
+	}
+	if t.dpdk == nil {
+		if err := t.detectDpdk(testpmdPath, ""); err != nil {
+			return err
+		}
 	}
-	clist := intToString(cset.ToSlice()[:nCores], ",")
 	t.filePrefix = shortuuid.New()
-	// setup socket-mem based on pci numa node
-	memNode0, memNode1 := 0, 0
-	for _, p := range pci {
-		if numa, err := getNumaNode(p); err == nil {
-			if numa == 0 {
-				memNode0 += 1024
-			} else if numa == 1 {
-				memNode1 += 1024
-			} else {
-				return fmt.Errorf("Only numa 0,1 are expected but numa %d is detected on pci %s", numa, p)
-			}
-		} else {
+	if t.socketMem == nil {
+		if err := t.planMemory(pci, vdevs, queues, ring, mbufSize); err != nil {
 			return err
 		}
 	}
-	cmd := fmt.Sprintf("%s --socket-mem %d,%d -n 4 --proc-type auto", testpmdPath, memNode0, memNode1)
-	cmd = fmt.Sprintf("%s -l %s", cmd, clist)
+	cmd := fmt.Sprintf("%s --socket-mem %s -n 4 --proc-type auto", t.testpmdPath, intToString(t.socketMem, ","))
+	cmd = fmt.Sprintf("%s -l %s %s %d", cmd, intToString(t.cores.lcores(), ","), t.dpdk.mainLcoreFlag, t.cores.mainLcore)
 	// use a unique file-prefix
 	cmd = fmt.Sprintf("%s --file-prefix %s", cmd, t.filePrefix)
 	// add each pci address
 	for _, p := range pci {
-		cmd = fmt.Sprintf("%s -w %s", cmd, p)
+		cmd = fmt.Sprintf("%s %s %s", cmd, t.dpdk.allowFlag, p)
+	}
+	// without an allowed device EAL would take every pci device on a dpdk driver
+	if len(pci) == 0 {
+		cmd = fmt.Sprintf("%s --no-pci", cmd)
+	}
+	// vdev ports come after the pci ones
+	for _, v := range vdevs {
+		cmd = fmt.Sprintf("%s --vdev %s", cmd, v)
+	}
+	for _, a := range ealArgs {
+		cmd = fmt.Sprintf("%s %s", cmd, a)
 	}
 	// this has to go first before the rest
 	cmd = fmt.Sprintf("%s -- -i", cmd)
@@ -76,61 +118,330 @@ func (t *testpmd) init(pci pciArray, queues int, ring int, testpmdPath string) e
 	cmd = fmt.Sprintf("%s --txq=%d", cmd, queues)
 	cmd = fmt.Sprintf("%s --rxd=%d", cmd, ring)
 	cmd = fmt.Sprintf("%s --txd=%d", cmd, ring)
+	cmd = fmt.Sprintf("%s --mbuf-size=%d", cmd, mbufSize)
+	for _, a := range t.noisy.args() {
+		cmd = fmt.Sprintf("%s %s", cmd, a)
+	}
 	log.Printf("cmd: %s", cmd)
-	e, _, err := expect.Spawn(cmd, startTimeout)
+	e, err := t.b.spawn(cmd, startTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to start testpmd: %v", err)
+	}
+	t.startTime = time.Now()
+	if _, _, err := e.Expect(promptRE, startTimeout); err != nil {
+		e.Close()
+		return fmt.Errorf("testpmd didn't start: %v", err)
+	}
+	t.cmdline = cmd
+	// without --max-pkt-len testpmd leaves the mtu at the 1500 of RTE_ETHER_MTU
+	t.portCfg = portSettings{rxq: queues, txq: queues, rxd: ring, txd: ring, mtu: 1500}
+	t.mbufSize = mbufSize
+	t.pid = t.b.pid(t.filePrefix)
+	// testpmd starts in io mode, forwarding stopped
+	t.fwdMode = "io"
+	t.x = newCmdExecutor(e)
+	if t.cores.pinned {
+		// the forwarding lcores take the streams in the order of the list, one each
+		cmd := "set corelist " + intToString(t.cores.pmdLcores, ",")
+		if err := t.runSetCmd(context.Background(), cmd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// planMemory sets up socket-mem based on pci numa node and checks the nodes have the huge pages for it.
+// It only reads sysfs, so it can run before the ports are bound.
+func (t *testpmd) planMemory(pci pciArray, vdevs vdevArray, queues int, ring int, mbufSize int) error {
+	// the noisy engine takes its lookup memory from the huge pages of each port
+	mem, err := socketMem(t.b.bus(), pci, len(vdevs), queues, ring, mbufSize, t.noisy.lkupMemory)
 	if err != nil {
-		log.Fatal(err)
+		return err
+	}
+	if err := checkHugepages(t.b.bus().root, mem, t.b.hugepageSizeKB()); err != nil {
+		if t.noisy.lkupMemory > 0 {
+			return fmt.Errorf("%v, lower -queues %d, -ring-size %d, -mbuf-size %d or -noisy-lkup-memory %d or add huge pages",
+				err, queues, ring, mbufSize, t.noisy.lkupMemory)
+		}
+		return fmt.Errorf("%v, lower -queues %d, -ring-size %d or -mbuf-size %d or add huge pages", err, queues, ring, mbufSize)
 	}
-	t.e = e
-	if _, _, err := t.e.Expect(promptRE, startTimeout); err != nil {
+	t.socketMem = mem
+	return nil
+}
+
+// detectDpdk finds the testpmd binary and the DPDK release whose options it takes.
+// A version given by the user skips the detection.
+func (t *testpmd) detectDpdk(testpmdPath string, version string) error {
+	path, detected, err := t.b.probe(testpmdPath)
+	if path == "" {
+		return err
+	}
+	t.testpmdPath = path
+	if version == "" {
+		if err != nil {
+			log.Printf("failed to detect the DPDK version of %s, assuming %s: %v", path, defaultDpdkRelease, err)
+			t.dpdk = defaultDpdkRelease
+			return nil
+		}
+		version = detected
+	}
+	release, err := dpdkReleaseFor(version)
+	if err != nil {
 		return err
 	}
+	log.Printf("%s is DPDK %s, using the options of release %s", path, version, release)
+	t.dpdkVersion, t.dpdk = version, release
 	return nil
 }
 
+// getDpdkVersion returns the DPDK version, the release whose options are used and the testpmd binary
+func (t *testpmd) getDpdkVersion() (string, *dpdkRelease, string) {
+	return t.dpdkVersion, t.dpdk, t.testpmdPath
+}
+
+// planCores picks the lcores, one extra core for mgmt in addition to the pmd.
+// With a queue map the pmd lcores are the mapped ones.
+func (t *testpmd) planCores(pci pciArray, vdevs vdevArray, queues int, policy string, qmap queueMapArray) error {
+	ports := len(pci) + len(vdevs)
+	var pinned []int
+	if len(qmap) > 0 {
+		var err error
+		if pinned, err = streamLcores(qmap, ports, queues); err != nil {
+			return err
+		}
+	}
+	var nodes []int
+	for _, p := range pci {
+		numa, err := t.b.bus().getNumaNode(p)
+		if err != nil {
+			return err
+		}
+		if numa >= 0 && !containsInt(nodes, numa) {
+			nodes = append(nodes, numa)
+		}
+	}
+	cpus := t.b.cpus()
+	plan, err := newCorePlan(readCpuTopology(t.b.bus().root, cpus), cpus, nodes, ports*queues, policy, pinned)
+	if err != nil {
+		return err
+	}
+	log.Printf("core plan: %s", plan)
+	t.cores = plan
+	return nil
+}
+
+// getCorePlan returns the lcores testpmd runs on
+func (t *testpmd) getCorePlan() *corePlan {
+	return t.cores
+}
+
+// getState returns the forwarding mode, whether forwarding is started and the testpmd start time
+func (t *testpmd) getState() (string, bool, time.Time) {
+	t.stateMu.Lock()
+	defer t.stateMu.Unlock()
+	return t.fwdMode, t.running, t.startTime
+}
+
+// runInfo is how testpmd was started
+type runInfo struct {
+	pid        int
+	filePrefix string
+	cmdline    string
+	ports      portSettings
+	mbufSize   int
+}
+
+func (t *testpmd) getRunInfo() runInfo {
+	t.stateMu.Lock()
+	defer t.stateMu.Unlock()
+	return runInfo{
+		pid:        t.pid,
+		filePrefix: t.filePrefix,
+		cmdline:    t.cmdline,
+		ports:      t.portCfg,
+		mbufSize:   t.mbufSize,
+	}
+}
+
+func (t *testpmd) setRunning(running bool) {
+	t.stateMu.Lock()
+	t.running = running
+	t.stateMu.Unlock()
+}
+
 func (t *testpmd) stop() error {
-	t.e.Close()
+	return t.x.close()
+}
+
+func (t *testpmd) runCmd(ctx context.Context, cmd string) (string, error) {
+	return t.runCmdTimeout(ctx, cmd, cmdTimeout)
+}
+
+func (t *testpmd) runCmdTimeout(ctx context.Context, cmd string, timeout time.Duration) (string, error) {
+	if t.x == nil {
+		return "", errNotRunning
+	}
+	output, err := t.x.run(ctx, cmd, timeout)
+	if err != nil {
+		return output, &cmdError{cmd: cmd, output: output, err: err}
+	}
+	return output, nil
+}
+
+// unexpectedOutput is a command whose output couldn't be parsed
+func unexpectedOutput(cmd string, output string, err error) error {
+	return &cmdError{cmd: cmd, output: output, err: fmt.Errorf("%w: %v", errUnexpectedOutput, err)}
+}
+
+// getPorts returns the pci addresses and vdev names in port order
+func (t *testpmd) getPorts() []string {
+	return t.ports
+}
+
+// checkPort fails if testpmd has no such pci address or vdev
+func (t *testpmd) checkPort(name string) error {
+	if !containsString(t.ports, name) {
+		return invalidArgument("unknown port %s, the ports are %s", name, strings.Join(t.ports, ", "))
+	}
 	return nil
 }
 
-func (t *testpmd) runCmd(cmd string) (string, error) {
-	t.e.Send(cmd + "\n")
-	output, _, err := t.e.Expect(promptRE, cmdTimeout)
-	log.Println(output)
-	return output, err
+// runSetCmd runs a configuration command and fails if testpmd rejects it
+func (t *testpmd) runSetCmd(ctx context.Context, cmd string) error {
+	return t.runSetCmdTimeout(ctx, cmd, cmdTimeout)
+}
+
+func (t *testpmd) runSetCmdTimeout(ctx context.Context, cmd string, timeout time.Duration) error {
+	output, err := t.runCmdTimeout(ctx, cmd, timeout)
+	if err != nil {
+		return err
+	}
+	if badArgsRE.MatchString(output) {
+		return &cmdError{cmd: cmd, output: output,
+			err: fmt.Errorf("%w: %s", errRejected, strings.TrimSpace(promptRE.ReplaceAllString(output, "")))}
+	}
+	return nil
 }
 
-func (t *testpmd) setFwdMode(mode string) error {
+// supportedFwdModes returns the forwarding modes the running testpmd reports
+func (t *testpmd) supportedFwdModes(ctx context.Context) ([]string, error) {
+	if t.fwdModes != nil {
+		return t.fwdModes, nil
+	}
+	output, err := t.runCmd(ctx, "help config")
+	if err != nil {
+		return nil, err
+	}
+	m := fwdModesRE.FindStringSubmatch(output)
+	if m == nil {
+		return nil, unexpectedOutput("help config", output, fmt.Errorf("no forwarding modes in the help"))
+	}
+	var modes []string
+	for _, mode := range strings.Split(m[1], "|") {
+		if mode = strings.TrimSpace(mode); mode != "" {
+			modes = append(modes, mode)
+		}
+	}
+	t.fwdModes = modes
+	return modes, nil
+}
+
+func (t *testpmd) setFwdMode(ctx context.Context, mode string) error {
+	return t.setFwdModeWith(ctx, mode, nil)
+}
+
+// setFwdModeWith restarts forwarding in the given mode, running setupCmds while forwarding is stopped
+func (t *testpmd) setFwdModeWith(ctx context.Context, mode string, setupCmds []string) error {
+	t.opMu.Lock()
+	defer t.opMu.Unlock()
+	if modes, err := t.supportedFwdModes(ctx); err != nil {
+		// older testpmd may not list them, "set fwd" still rejects an unknown mode
+		log.Printf("can't validate forwarding mode %s: %v", mode, err)
+	} else if !containsString(modes, mode) {
+		return invalidArgument("forwarding mode %s is not supported by this testpmd, supported modes: %s",
+			mode, strings.Join(modes, ", "))
+	}
 	if t.running {
-		if _, err := t.runCmd("stop"); err != nil {
+		if _, err := t.runCmd(ctx, "stop"); err != nil {
 			return err
 		}
-		t.running = false
+		t.setRunning(false)
 	}
-	if _, err := t.runCmd("set fwd " + mode); err != nil {
+	for _, cmd := range setupCmds {
+		if err := t.runSetCmd(ctx, cmd); err != nil {
+			return err
+		}
+	}
+	if err := t.runSetCmd(ctx, "set fwd "+mode); err != nil {
 		return err
 	}
-	if _, err := t.runCmd("start"); err != nil {
+	t.stateMu.Lock()
+	t.fwdMode = mode
+	t.stateMu.Unlock()
+	return t.runStart(ctx)
+}
+
+// startFwd starts forwarding in the current mode
+func (t *testpmd) startFwd(ctx context.Context) error {
+	t.opMu.Lock()
+	defer t.opMu.Unlock()
+	if t.running {
+		return nil
+	}
+	return t.runStart(ctx)
+}
+
+// runStart starts forwarding, running is only set once testpmd reports it forwards
+func (t *testpmd) runStart(ctx context.Context) error {
+	output, err := t.runCmd(ctx, "start")
+	if err != nil {
 		return err
 	}
-	t.running = true
+	if startRejectedRE.MatchString(output) || badArgsRE.MatchString(output) {
+		return &cmdError{cmd: "start", output: output,
+			err: fmt.Errorf("%w: %s", errNotForwarding, strings.TrimSpace(promptRE.ReplaceAllString(output, "")))}
+	}
+	t.setRunning(true)
 	return nil
 }
 
-func (t *testpmd) icmpMode() error {
-	return t.setFwdMode("icmpecho")
+// stopFwd stops forwarding without changing the mode and returns the statistics printed by "stop".
+// If forwarding is already stopped, the statistics of the last run are still there until the next start.
+func (t *testpmd) stopFwd(ctx context.Context) (*pb.FwdStats, error) {
+	t.opMu.Lock()
+	defer t.opMu.Unlock()
+	if !t.running {
+		return t.getFwdStats(ctx)
+	}
+	output, err := t.runCmd(ctx, "stop")
+	if err != nil {
+		return nil, err
+	}
+	t.setRunning(false)
+	stats, err := parseFwdStats(output)
+	if err != nil {
+		return nil, unexpectedOutput("stop", output, err)
+	}
+	return stats, nil
+}
+
+func (t *testpmd) icmpMode(ctx context.Context) error {
+	return t.setFwdMode(ctx, "icmpecho")
 }
 
-func (t *testpmd) ioMode() error {
-	return t.setFwdMode("io")
+func (t *testpmd) ioMode(ctx context.Context) error {
+	return t.setFwdMode(ctx, "io")
 }
 
-func (t *testpmd) macMode() error {
-	return t.setFwdMode("mac")
+func (t *testpmd) macMode(ctx context.Context) error {
+	return t.setFwdMode(ctx, "mac")
 }
 
-func (t *testpmd) getMacAddress(pci string) (string, error) {
-	output, err := t.runCmd("show device info " + pci)
+func (t *testpmd) getMacAddress(ctx context.Context, pci string) (string, error) {
+	if err := t.checkPort(pci); err != nil {
+		return "", err
+	}
+	output, err := t.runCmd(ctx, "show device info "+pci)
 	if err != nil {
 		return "", err
 	}
@@ -141,47 +452,92 @@ func (t *testpmd) getMacAddress(pci string) (string, error) {
 			return mac, nil
 		}
 	}
-	return "", fmt.Errorf("couldn't get mac address for pci slot %s", pci)
+	return "", unexpectedOutput("show device info "+pci, output, fmt.Errorf("no mac address for port %s", pci))
 }
 
-func (t *testpmd) setPeerMac(portNum int32, peerMac string) error {
+func (t *testpmd) setPeerMac(ctx context.Context, portNum int32, peerMac string) error {
+	if portNum < 0 || int(portNum) >= len(t.ports) {
+		return invalidArgument("invalid port number %d, testpmd has %d ports", portNum, len(t.ports))
+	}
+	if _, err := net.ParseMAC(peerMac); err != nil {
+		return invalidArgument("invalid peer mac of port %d: %v", portNum, err)
+	}
+	t.opMu.Lock()
+	defer t.opMu.Unlock()
 	if t.running {
-		if _, err := t.runCmd("stop"); err != nil {
+		if _, err := t.runCmd(ctx, "stop"); err != nil {
 			return err
 		}
-		t.running = false
+		t.setRunning(false)
 	}
-	cmd := fmt.Sprintf("set eth-peer %d %s", portNum, peerMac)
-	_, err := t.runCmd(cmd)
-	return err
+	return t.runSetCmd(ctx, fmt.Sprintf("set eth-peer %d %s", portNum, peerMac))
 }
 
-func (t *testpmd) listPorts() (string, error) {
-	return t.runCmd("show device info all")
+func (t *testpmd) listPorts(ctx context.Context) (string, error) {
+	return t.runCmd(ctx, "show device info all")
 }
 
-func (t *testpmd) getPortInfo(pci string) (string, error) {
-	return t.runCmd("show device info " + pci)
+func (t *testpmd) getPortInfo(ctx context.Context, pci string) (string, error) {
+	if err := t.checkPort(pci); err != nil {
+		return "", err
+	}
+	return t.runCmd(ctx, "show device info "+pci)
 }
 
-func (t *testpmd) getFwdInfo() (string, error) {
-	return t.runCmd("show fwd stats all")
+func (t *testpmd) getFwdInfo(ctx context.Context) (string, error) {
+	return t.runCmd(ctx, "show fwd stats all")
 }
 
-func (t *testpmd) clearFwdInfo() (string, error) {
-	return t.runCmd("clear fwd stats all")
+func (t *testpmd) getFwdConfig(ctx context.Context) (*pb.ForwardingConfig, error) {
+	output, err := t.runCmd(ctx, "show config fwd")
+	if err != nil {
+		return nil, err
+	}
+	config, err := parseFwdConfig(output)
+	if err != nil {
+		return nil, unexpectedOutput("show config fwd", output, err)
+	}
+	return config, nil
+}
+
+func (t *testpmd) getFwdStats(ctx context.Context) (*pb.FwdStats, error) {
+	output, err := t.getFwdInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := parseFwdStats(output)
+	if err != nil {
+		return nil, unexpectedOutput("show fwd stats all", output, err)
+	}
+	return stats, nil
+}
+
+func (t *testpmd) getPortCounters(ctx context.Context) ([]*portCounters, error) {
+	output, err := t.runCmd(ctx, "show port stats all")
+	if err != nil {
+		return nil, err
+	}
+	counters, err := parsePortStats(output)
+	if err != nil {
+		return nil, unexpectedOutput("show port stats all", output, err)
+	}
+	return counters, nil
+}
+
+func (t *testpmd) clearFwdInfo(ctx context.Context) (string, error) {
+	return t.runCmd(ctx, "clear fwd stats all")
 }
 
 func (t *testpmd) releaseHugePages() error {
-	files, err := filepath.Glob(fmt.Sprintf("/dev/hugepages/%s*", t.filePrefix))
+	files, err := filepath.Glob(filepath.Join(hugepagesDir, t.filePrefix+"*"))
 	if err != nil {
-    		return err
+		return err
 	}
 
 	for _, f := range files {
-	    	if err := os.Remove(f); err != nil {
-        		return err
-    		}
+		if err := os.Remove(f); err != nil {
+			return err
+		}
 	}
 	return nil
 }
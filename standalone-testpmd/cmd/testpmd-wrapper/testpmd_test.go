@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+// newSimTestpmd starts a simulated testpmd with two pci ports, the returned function stops it
+func newSimTestpmd(t *testing.T) (*testpmd, func()) {
+	t.Helper()
+	pci := pciArray{"0000:86:00.0", "0000:86:00.1"}
+	b, err := newSimBackend(pci, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tp := &testpmd{b: b}
+	if err := tp.init(pci, nil, nil, 1, 512, defaultMbufSize, "dpdk-testpmd"); err != nil {
+		b.close()
+		t.Fatal(err)
+	}
+	return tp, func() {
+		tp.stop()
+		b.close()
+	}
+}
+
+func TestStartRejected(t *testing.T) {
+	tp, stop := newSimTestpmd(t)
+	defer stop()
+	ctx := context.Background()
+	if err := tp.runSetCmd(ctx, "port stop all"); err != nil {
+		t.Fatal(err)
+	}
+	for name, start := range map[string]func() error{
+		"start":    func() error { return tp.startFwd(ctx) },
+		"set mode": func() error { return tp.setFwdMode(ctx, "macswap") },
+	} {
+		err := start()
+		checkCode(t, grpcError(err), codes.FailedPrecondition)
+		if _, running, _ := tp.getState(); running {
+			t.Errorf("%s: running after testpmd refused to start", name)
+		}
+	}
+
+	if err := tp.runSetCmd(ctx, "port start all"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tp.startFwd(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, running, _ := tp.getState(); !running {
+		t.Error("not running after start")
+	}
+}
@@ -17,9 +17,27 @@ func intToString(a []int, delim string) string {
 }
 
 func portMask(ports int) string {
-	var a uint8
+	var a uint64
 	for i := 0; i < ports; i++ {
 		a = a | (1 << i)
 	}
 	return fmt.Sprintf("%#x", a)
 }
+
+func containsString(a []string, s string) bool {
+	for _, v := range a {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(a []int, i int) bool {
+	for _, v := range a {
+		if v == i {
+			return true
+		}
+	}
+	return false
+}
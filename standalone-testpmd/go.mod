@@ -50,9 +50,14 @@ require (
 	github.com/golang/protobuf v1.4.3
 	github.com/google/goexpect v0.0.0-20200816234442-b5b77125c2c5
 	github.com/lithammer/shortuuid v3.0.0+incompatible
+	github.com/prometheus/client_golang v1.7.1
+	github.com/vishvananda/netlink v1.1.0
+	golang.org/x/sys v0.0.0-20200622214017-ed371f2e16b4
+	google.golang.org/genproto v0.0.0-20200526211855-cb27e3aa2013
 	google.golang.org/grpc v1.33.0-dev
 	google.golang.org/protobuf v1.25.0
 	k8s.io/kubernetes v1.19.1
+	sigs.k8s.io/yaml v1.2.0
 )
 
 replace k8s.io/sample-cli-plugin => k8s.io/sample-cli-plugin v0.19.1
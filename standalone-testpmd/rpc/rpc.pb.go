@@ -26,6 +26,78 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+type FwdEngine int32
+
+const (
+	FwdEngine_FWD_IO          FwdEngine = 0
+	FwdEngine_FWD_MAC         FwdEngine = 1
+	FwdEngine_FWD_MACSWAP     FwdEngine = 2
+	FwdEngine_FWD_FLOWGEN     FwdEngine = 3
+	FwdEngine_FWD_RXONLY      FwdEngine = 4
+	FwdEngine_FWD_TXONLY      FwdEngine = 5
+	FwdEngine_FWD_CSUM        FwdEngine = 6
+	FwdEngine_FWD_ICMPECHO    FwdEngine = 7
+	FwdEngine_FWD_5TUPLE_SWAP FwdEngine = 8
+	// the noisy engine buffer and lookup sizes are testpmd startup options,
+	// set with the -noisy-* flags of the wrapper
+	FwdEngine_FWD_NOISY FwdEngine = 9
+)
+
+// Enum value maps for FwdEngine.
+var (
+	FwdEngine_name = map[int32]string{
+		0: "FWD_IO",
+		1: "FWD_MAC",
+		2: "FWD_MACSWAP",
+		3: "FWD_FLOWGEN",
+		4: "FWD_RXONLY",
+		5: "FWD_TXONLY",
+		6: "FWD_CSUM",
+		7: "FWD_ICMPECHO",
+		8: "FWD_5TUPLE_SWAP",
+		9: "FWD_NOISY",
+	}
+	FwdEngine_value = map[string]int32{
+		"FWD_IO":          0,
+		"FWD_MAC":         1,
+		"FWD_MACSWAP":     2,
+		"FWD_FLOWGEN":     3,
+		"FWD_RXONLY":      4,
+		"FWD_TXONLY":      5,
+		"FWD_CSUM":        6,
+		"FWD_ICMPECHO":    7,
+		"FWD_5TUPLE_SWAP": 8,
+		"FWD_NOISY":       9,
+	}
+)
+
+func (x FwdEngine) Enum() *FwdEngine {
+	p := new(FwdEngine)
+	*p = x
+	return p
+}
+
+func (x FwdEngine) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FwdEngine) Descriptor() protoreflect.EnumDescriptor {
+	return file_rpc_proto_enumTypes[0].Descriptor()
+}
+
+func (FwdEngine) Type() protoreflect.EnumType {
+	return &file_rpc_proto_enumTypes[0]
+}
+
+func (x FwdEngine) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FwdEngine.Descriptor instead.
+func (FwdEngine) EnumDescriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{0}
+}
+
 type Success struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -426,190 +498,1295 @@ func (x *FwdInfo) GetFwdInfoStr() string {
 	return ""
 }
 
-var File_rpc_proto protoreflect.FileDescriptor
+type PortFwdStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_rpc_proto_rawDesc = []byte{
-	0x0a, 0x09, 0x72, 0x70, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x74, 0x65, 0x73,
-	0x74, 0x70, 0x6d, 0x64, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0x23, 0x0a, 0x07, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07,
-	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73,
-	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x2c, 0x0a, 0x0a, 0x4d, 0x61, 0x63, 0x41, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65,
-	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x63, 0x41, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x22, 0x39, 0x0a, 0x08, 0x50, 0x6f, 0x72, 0x74, 0x4c, 0x69, 0x73, 0x74,
-	0x12, 0x2d, 0x0a, 0x08, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x50, 0x6f, 0x72,
-	0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x22,
-	0x64, 0x0a, 0x08, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x70,
-	0x6f, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x70, 0x6f,
-	0x72, 0x74, 0x4e, 0x75, 0x6d, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x63, 0x41, 0x64, 0x64, 0x72,
-	0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x63, 0x41, 0x64,
-	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x63, 0x69, 0x41, 0x64, 0x64, 0x72,
-	0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x63, 0x69, 0x41, 0x64,
-	0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x25, 0x0a, 0x03, 0x50, 0x63, 0x69, 0x12, 0x1e, 0x0a, 0x0a,
-	0x70, 0x63, 0x69, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0a, 0x70, 0x63, 0x69, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x43, 0x0a, 0x07,
-	0x50, 0x65, 0x65, 0x72, 0x4d, 0x61, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x6f, 0x72, 0x74, 0x4e,
-	0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x70, 0x6f, 0x72, 0x74, 0x4e, 0x75,
-	0x6d, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x22, 0x36, 0x0a, 0x08, 0x50, 0x65, 0x65, 0x72, 0x4d, 0x61, 0x63, 0x73, 0x12, 0x2a, 0x0a,
-	0x07, 0x70, 0x65, 0x65, 0x72, 0x4d, 0x61, 0x63, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
-	0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x4d, 0x61, 0x63,
-	0x52, 0x07, 0x70, 0x65, 0x65, 0x72, 0x4d, 0x61, 0x63, 0x22, 0x29, 0x0a, 0x07, 0x46, 0x77, 0x64,
-	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x77, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x53,
-	0x74, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x77, 0x64, 0x49, 0x6e, 0x66,
-	0x6f, 0x53, 0x74, 0x72, 0x32, 0xb1, 0x03, 0x0a, 0x07, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64,
-	0x12, 0x32, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x12, 0x0c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x50, 0x63, 0x69, 0x1a,
-	0x13, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x4d, 0x61, 0x63, 0x41, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x12, 0x2e, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x49,
-	0x6e, 0x66, 0x6f, 0x12, 0x0c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x50, 0x63,
-	0x69, 0x1a, 0x11, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x50, 0x6f, 0x72, 0x74,
-	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x36, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x72, 0x74,
-	0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x74, 0x65, 0x73, 0x74,
-	0x70, 0x6d, 0x64, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x08,
-	0x49, 0x63, 0x6d, 0x70, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x1a, 0x10, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65,
-	0x73, 0x73, 0x12, 0x32, 0x0a, 0x06, 0x49, 0x6f, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x53,
-	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x4d, 0x61, 0x63, 0x4d, 0x6f, 0x64,
-	0x65, 0x12, 0x11, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x50, 0x65, 0x65, 0x72,
-	0x4d, 0x61, 0x63, 0x73, 0x1a, 0x10, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x53,
-	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x36, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x46, 0x77, 0x64,
-	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x74,
-	0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x46, 0x77, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x38,
-	0x0a, 0x0c, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x46, 0x77, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64,
-	0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x42, 0x45, 0x5a, 0x43, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x65, 0x64, 0x68, 0x61, 0x74, 0x2d, 0x6e, 0x66,
-	0x76, 0x70, 0x65, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2d, 0x70, 0x65,
-	0x72, 0x66, 0x2d, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x2f, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x61, 0x6c,
-	0x6f, 0x6e, 0x65, 0x2d, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2f, 0x72, 0x70, 0x63, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	PortNum          int32  `protobuf:"varint,1,opt,name=portNum,proto3" json:"portNum,omitempty"`
+	RxPackets        uint64 `protobuf:"varint,2,opt,name=rxPackets,proto3" json:"rxPackets,omitempty"`
+	RxDropped        uint64 `protobuf:"varint,3,opt,name=rxDropped,proto3" json:"rxDropped,omitempty"`
+	RxTotal          uint64 `protobuf:"varint,4,opt,name=rxTotal,proto3" json:"rxTotal,omitempty"`
+	TxPackets        uint64 `protobuf:"varint,5,opt,name=txPackets,proto3" json:"txPackets,omitempty"`
+	TxDropped        uint64 `protobuf:"varint,6,opt,name=txDropped,proto3" json:"txDropped,omitempty"`
+	TxTotal          uint64 `protobuf:"varint,7,opt,name=txTotal,proto3" json:"txTotal,omitempty"`
+	RxBadIpCsum      uint64 `protobuf:"varint,8,opt,name=rxBadIpCsum,proto3" json:"rxBadIpCsum,omitempty"`
+	RxBadL4Csum      uint64 `protobuf:"varint,9,opt,name=rxBadL4Csum,proto3" json:"rxBadL4Csum,omitempty"`
+	RxBadOuterL4Csum uint64 `protobuf:"varint,10,opt,name=rxBadOuterL4Csum,proto3" json:"rxBadOuterL4Csum,omitempty"`
 }
 
-var (
-	file_rpc_proto_rawDescOnce sync.Once
-	file_rpc_proto_rawDescData = file_rpc_proto_rawDesc
-)
+func (x *PortFwdStats) Reset() {
+	*x = PortFwdStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_rpc_proto_rawDescGZIP() []byte {
-	file_rpc_proto_rawDescOnce.Do(func() {
-		file_rpc_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_proto_rawDescData)
-	})
-	return file_rpc_proto_rawDescData
+func (x *PortFwdStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_rpc_proto_goTypes = []interface{}{
-	(*Success)(nil),     // 0: testpmd.Success
-	(*MacAddress)(nil),  // 1: testpmd.MacAddress
-	(*PortList)(nil),    // 2: testpmd.PortList
-	(*PortInfo)(nil),    // 3: testpmd.PortInfo
-	(*Pci)(nil),         // 4: testpmd.Pci
-	(*PeerMac)(nil),     // 5: testpmd.PeerMac
-	(*PeerMacs)(nil),    // 6: testpmd.PeerMacs
-	(*FwdInfo)(nil),     // 7: testpmd.FwdInfo
-	(*empty.Empty)(nil), // 8: google.protobuf.Empty
+func (*PortFwdStats) ProtoMessage() {}
+
+func (x *PortFwdStats) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_rpc_proto_depIdxs = []int32{
-	3,  // 0: testpmd.PortList.portInfo:type_name -> testpmd.PortInfo
-	5,  // 1: testpmd.PeerMacs.peerMac:type_name -> testpmd.PeerMac
-	4,  // 2: testpmd.testpmd.GetMacAddress:input_type -> testpmd.Pci
-	4,  // 3: testpmd.testpmd.GetPortInfo:input_type -> testpmd.Pci
-	8,  // 4: testpmd.testpmd.ListPorts:input_type -> google.protobuf.Empty
-	8,  // 5: testpmd.testpmd.IcmpMode:input_type -> google.protobuf.Empty
-	8,  // 6: testpmd.testpmd.IoMode:input_type -> google.protobuf.Empty
-	6,  // 7: testpmd.testpmd.MacMode:input_type -> testpmd.PeerMacs
-	8,  // 8: testpmd.testpmd.GetFwdInfo:input_type -> google.protobuf.Empty
-	8,  // 9: testpmd.testpmd.ClearFwdInfo:input_type -> google.protobuf.Empty
-	1,  // 10: testpmd.testpmd.GetMacAddress:output_type -> testpmd.MacAddress
-	3,  // 11: testpmd.testpmd.GetPortInfo:output_type -> testpmd.PortInfo
-	2,  // 12: testpmd.testpmd.ListPorts:output_type -> testpmd.PortList
-	0,  // 13: testpmd.testpmd.IcmpMode:output_type -> testpmd.Success
-	0,  // 14: testpmd.testpmd.IoMode:output_type -> testpmd.Success
-	0,  // 15: testpmd.testpmd.MacMode:output_type -> testpmd.Success
-	7,  // 16: testpmd.testpmd.GetFwdInfo:output_type -> testpmd.FwdInfo
-	0,  // 17: testpmd.testpmd.ClearFwdInfo:output_type -> testpmd.Success
-	10, // [10:18] is the sub-list for method output_type
-	2,  // [2:10] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+
+// Deprecated: Use PortFwdStats.ProtoReflect.Descriptor instead.
+func (*PortFwdStats) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{8}
 }
 
-func init() { file_rpc_proto_init() }
-func file_rpc_proto_init() {
-	if File_rpc_proto != nil {
-		return
+func (x *PortFwdStats) GetPortNum() int32 {
+	if x != nil {
+		return x.PortNum
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_rpc_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Success); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_rpc_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MacAddress); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_rpc_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PortList); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_rpc_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PortInfo); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return 0
+}
+
+func (x *PortFwdStats) GetRxPackets() uint64 {
+	if x != nil {
+		return x.RxPackets
+	}
+	return 0
+}
+
+func (x *PortFwdStats) GetRxDropped() uint64 {
+	if x != nil {
+		return x.RxDropped
+	}
+	return 0
+}
+
+func (x *PortFwdStats) GetRxTotal() uint64 {
+	if x != nil {
+		return x.RxTotal
+	}
+	return 0
+}
+
+func (x *PortFwdStats) GetTxPackets() uint64 {
+	if x != nil {
+		return x.TxPackets
+	}
+	return 0
+}
+
+func (x *PortFwdStats) GetTxDropped() uint64 {
+	if x != nil {
+		return x.TxDropped
+	}
+	return 0
+}
+
+func (x *PortFwdStats) GetTxTotal() uint64 {
+	if x != nil {
+		return x.TxTotal
+	}
+	return 0
+}
+
+func (x *PortFwdStats) GetRxBadIpCsum() uint64 {
+	if x != nil {
+		return x.RxBadIpCsum
+	}
+	return 0
+}
+
+func (x *PortFwdStats) GetRxBadL4Csum() uint64 {
+	if x != nil {
+		return x.RxBadL4Csum
+	}
+	return 0
+}
+
+func (x *PortFwdStats) GetRxBadOuterL4Csum() uint64 {
+	if x != nil {
+		return x.RxBadOuterL4Csum
+	}
+	return 0
+}
+
+type FwdStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PortStats []*PortFwdStats `protobuf:"bytes,1,rep,name=portStats,proto3" json:"portStats,omitempty"`
+	// accumulated counters for all ports, portNum is -1
+	Accumulated *PortFwdStats `protobuf:"bytes,2,opt,name=accumulated,proto3" json:"accumulated,omitempty"`
+}
+
+func (x *FwdStats) Reset() {
+	*x = FwdStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FwdStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FwdStats) ProtoMessage() {}
+
+func (x *FwdStats) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_rpc_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Pci); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FwdStats.ProtoReflect.Descriptor instead.
+func (*FwdStats) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FwdStats) GetPortStats() []*PortFwdStats {
+	if x != nil {
+		return x.PortStats
+	}
+	return nil
+}
+
+func (x *FwdStats) GetAccumulated() *PortFwdStats {
+	if x != nil {
+		return x.Accumulated
+	}
+	return nil
+}
+
+type ThroughputRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// sampling interval in milliseconds, 1000 if not set
+	IntervalMs uint32 `protobuf:"varint,1,opt,name=intervalMs,proto3" json:"intervalMs,omitempty"`
+}
+
+func (x *ThroughputRequest) Reset() {
+	*x = ThroughputRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ThroughputRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThroughputRequest) ProtoMessage() {}
+
+func (x *ThroughputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThroughputRequest.ProtoReflect.Descriptor instead.
+func (*ThroughputRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ThroughputRequest) GetIntervalMs() uint32 {
+	if x != nil {
+		return x.IntervalMs
+	}
+	return 0
+}
+
+type PortThroughput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PortNum int32   `protobuf:"varint,1,opt,name=portNum,proto3" json:"portNum,omitempty"`
+	RxPps   float64 `protobuf:"fixed64,2,opt,name=rxPps,proto3" json:"rxPps,omitempty"`
+	TxPps   float64 `protobuf:"fixed64,3,opt,name=txPps,proto3" json:"txPps,omitempty"`
+	RxBps   float64 `protobuf:"fixed64,4,opt,name=rxBps,proto3" json:"rxBps,omitempty"`
+	TxBps   float64 `protobuf:"fixed64,5,opt,name=txBps,proto3" json:"txBps,omitempty"`
+	// packets per second missed, in error or without mbuf on rx
+	RxDropPps  float64 `protobuf:"fixed64,6,opt,name=rxDropPps,proto3" json:"rxDropPps,omitempty"`
+	TxErrorPps float64 `protobuf:"fixed64,7,opt,name=txErrorPps,proto3" json:"txErrorPps,omitempty"`
+}
+
+func (x *PortThroughput) Reset() {
+	*x = PortThroughput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PortThroughput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortThroughput) ProtoMessage() {}
+
+func (x *PortThroughput) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortThroughput.ProtoReflect.Descriptor instead.
+func (*PortThroughput) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PortThroughput) GetPortNum() int32 {
+	if x != nil {
+		return x.PortNum
+	}
+	return 0
+}
+
+func (x *PortThroughput) GetRxPps() float64 {
+	if x != nil {
+		return x.RxPps
+	}
+	return 0
+}
+
+func (x *PortThroughput) GetTxPps() float64 {
+	if x != nil {
+		return x.TxPps
+	}
+	return 0
+}
+
+func (x *PortThroughput) GetRxBps() float64 {
+	if x != nil {
+		return x.RxBps
+	}
+	return 0
+}
+
+func (x *PortThroughput) GetTxBps() float64 {
+	if x != nil {
+		return x.TxBps
+	}
+	return 0
+}
+
+func (x *PortThroughput) GetRxDropPps() float64 {
+	if x != nil {
+		return x.RxDropPps
+	}
+	return 0
+}
+
+func (x *PortThroughput) GetTxErrorPps() float64 {
+	if x != nil {
+		return x.TxErrorPps
+	}
+	return 0
+}
+
+type Throughput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// unix time in milliseconds when the counters were sampled
+	TimestampMs int64 `protobuf:"varint,1,opt,name=timestampMs,proto3" json:"timestampMs,omitempty"`
+	// actual time between this sample and the previous one
+	IntervalMs     uint32            `protobuf:"varint,2,opt,name=intervalMs,proto3" json:"intervalMs,omitempty"`
+	PortThroughput []*PortThroughput `protobuf:"bytes,3,rep,name=portThroughput,proto3" json:"portThroughput,omitempty"`
+}
+
+func (x *Throughput) Reset() {
+	*x = Throughput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Throughput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Throughput) ProtoMessage() {}
+
+func (x *Throughput) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Throughput.ProtoReflect.Descriptor instead.
+func (*Throughput) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Throughput) GetTimestampMs() int64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+func (x *Throughput) GetIntervalMs() uint32 {
+	if x != nil {
+		return x.IntervalMs
+	}
+	return 0
+}
+
+func (x *Throughput) GetPortThroughput() []*PortThroughput {
+	if x != nil {
+		return x.PortThroughput
+	}
+	return nil
+}
+
+// packet layout for the txonly and flowgen engines
+type TxPacketParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// length of each segment of the generated packets, "set txpkts"
+	SegmentLengths []uint32 `protobuf:"varint,1,rep,packed,name=segmentLengths,proto3" json:"segmentLengths,omitempty"`
+	// packets per burst, "set burst", unchanged if 0
+	Burst uint32 `protobuf:"varint,2,opt,name=burst,proto3" json:"burst,omitempty"`
+}
+
+func (x *TxPacketParams) Reset() {
+	*x = TxPacketParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TxPacketParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxPacketParams) ProtoMessage() {}
+
+func (x *TxPacketParams) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxPacketParams.ProtoReflect.Descriptor instead.
+func (*TxPacketParams) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TxPacketParams) GetSegmentLengths() []uint32 {
+	if x != nil {
+		return x.SegmentLengths
+	}
+	return nil
+}
+
+func (x *TxPacketParams) GetBurst() uint32 {
+	if x != nil {
+		return x.Burst
+	}
+	return 0
+}
+
+type ForwardingMode struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Engine FwdEngine `protobuf:"varint,1,opt,name=engine,proto3,enum=testpmd.FwdEngine" json:"engine,omitempty"`
+	// Types that are assignable to Params:
+	//	*ForwardingMode_TxPacket
+	//	*ForwardingMode_PeerMacs
+	Params isForwardingMode_Params `protobuf_oneof:"params"`
+}
+
+func (x *ForwardingMode) Reset() {
+	*x = ForwardingMode{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForwardingMode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardingMode) ProtoMessage() {}
+
+func (x *ForwardingMode) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardingMode.ProtoReflect.Descriptor instead.
+func (*ForwardingMode) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ForwardingMode) GetEngine() FwdEngine {
+	if x != nil {
+		return x.Engine
+	}
+	return FwdEngine_FWD_IO
+}
+
+func (m *ForwardingMode) GetParams() isForwardingMode_Params {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
+func (x *ForwardingMode) GetTxPacket() *TxPacketParams {
+	if x, ok := x.GetParams().(*ForwardingMode_TxPacket); ok {
+		return x.TxPacket
+	}
+	return nil
+}
+
+func (x *ForwardingMode) GetPeerMacs() *PeerMacs {
+	if x, ok := x.GetParams().(*ForwardingMode_PeerMacs); ok {
+		return x.PeerMacs
+	}
+	return nil
+}
+
+type isForwardingMode_Params interface {
+	isForwardingMode_Params()
+}
+
+type ForwardingMode_TxPacket struct {
+	// txonly and flowgen only
+	TxPacket *TxPacketParams `protobuf:"bytes,2,opt,name=txPacket,proto3,oneof"`
+}
+
+type ForwardingMode_PeerMacs struct {
+	// mac only
+	PeerMacs *PeerMacs `protobuf:"bytes,3,opt,name=peerMacs,proto3,oneof"`
+}
+
+func (*ForwardingMode_TxPacket) isForwardingMode_Params() {}
+
+func (*ForwardingMode_PeerMacs) isForwardingMode_Params() {}
+
+type Lcore struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// -1 if unknown
+	NumaNode int32 `protobuf:"varint,2,opt,name=numaNode,proto3" json:"numaNode,omitempty"`
+	// the other hardware threads of the physical core
+	Siblings []int32 `protobuf:"varint,3,rep,packed,name=siblings,proto3" json:"siblings,omitempty"`
+	// on a numa node of the ports
+	Local bool `protobuf:"varint,4,opt,name=local,proto3" json:"local,omitempty"`
+}
+
+func (x *Lcore) Reset() {
+	*x = Lcore{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Lcore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lcore) ProtoMessage() {}
+
+func (x *Lcore) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lcore.ProtoReflect.Descriptor instead.
+func (*Lcore) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Lcore) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Lcore) GetNumaNode() int32 {
+	if x != nil {
+		return x.NumaNode
+	}
+	return 0
+}
+
+func (x *Lcore) GetSiblings() []int32 {
+	if x != nil {
+		return x.Siblings
+	}
+	return nil
+}
+
+func (x *Lcore) GetLocal() bool {
+	if x != nil {
+		return x.Local
+	}
+	return false
+}
+
+type CorePlan struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// local-strict, local-preferred or any
+	Policy        string   `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	PortNumaNodes []int32  `protobuf:"varint,2,rep,packed,name=portNumaNodes,proto3" json:"portNumaNodes,omitempty"`
+	MainLcore     *Lcore   `protobuf:"bytes,3,opt,name=mainLcore,proto3" json:"mainLcore,omitempty"`
+	PmdLcores     []*Lcore `protobuf:"bytes,4,rep,name=pmdLcores,proto3" json:"pmdLcores,omitempty"`
+}
+
+func (x *CorePlan) Reset() {
+	*x = CorePlan{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CorePlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CorePlan) ProtoMessage() {}
+
+func (x *CorePlan) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CorePlan.ProtoReflect.Descriptor instead.
+func (*CorePlan) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CorePlan) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+func (x *CorePlan) GetPortNumaNodes() []int32 {
+	if x != nil {
+		return x.PortNumaNodes
+	}
+	return nil
+}
+
+func (x *CorePlan) GetMainLcore() *Lcore {
+	if x != nil {
+		return x.MainLcore
+	}
+	return nil
+}
+
+func (x *CorePlan) GetPmdLcores() []*Lcore {
+	if x != nil {
+		return x.PmdLcores
+	}
+	return nil
+}
+
+// a forwarding stream of "show config fwd"
+type FwdStream struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lcore       int32  `protobuf:"varint,1,opt,name=lcore,proto3" json:"lcore,omitempty"`
+	LcoreSocket int32  `protobuf:"varint,2,opt,name=lcoreSocket,proto3" json:"lcoreSocket,omitempty"`
+	RxPort      int32  `protobuf:"varint,3,opt,name=rxPort,proto3" json:"rxPort,omitempty"`
+	RxQueue     int32  `protobuf:"varint,4,opt,name=rxQueue,proto3" json:"rxQueue,omitempty"`
+	TxPort      int32  `protobuf:"varint,5,opt,name=txPort,proto3" json:"txPort,omitempty"`
+	TxQueue     int32  `protobuf:"varint,6,opt,name=txQueue,proto3" json:"txQueue,omitempty"`
+	PeerMac     string `protobuf:"bytes,7,opt,name=peerMac,proto3" json:"peerMac,omitempty"`
+}
+
+func (x *FwdStream) Reset() {
+	*x = FwdStream{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FwdStream) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FwdStream) ProtoMessage() {}
+
+func (x *FwdStream) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FwdStream.ProtoReflect.Descriptor instead.
+func (*FwdStream) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FwdStream) GetLcore() int32 {
+	if x != nil {
+		return x.Lcore
+	}
+	return 0
+}
+
+func (x *FwdStream) GetLcoreSocket() int32 {
+	if x != nil {
+		return x.LcoreSocket
+	}
+	return 0
+}
+
+func (x *FwdStream) GetRxPort() int32 {
+	if x != nil {
+		return x.RxPort
+	}
+	return 0
+}
+
+func (x *FwdStream) GetRxQueue() int32 {
+	if x != nil {
+		return x.RxQueue
+	}
+	return 0
+}
+
+func (x *FwdStream) GetTxPort() int32 {
+	if x != nil {
+		return x.TxPort
+	}
+	return 0
+}
+
+func (x *FwdStream) GetTxQueue() int32 {
+	if x != nil {
+		return x.TxQueue
+	}
+	return 0
+}
+
+func (x *FwdStream) GetPeerMac() string {
+	if x != nil {
+		return x.PeerMac
+	}
+	return ""
+}
+
+type ForwardingConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// forwarding mode, e.g. io
+	Mode       string       `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	NumPorts   int32        `protobuf:"varint,2,opt,name=numPorts,proto3" json:"numPorts,omitempty"`
+	NumCores   int32        `protobuf:"varint,3,opt,name=numCores,proto3" json:"numCores,omitempty"`
+	NumStreams int32        `protobuf:"varint,4,opt,name=numStreams,proto3" json:"numStreams,omitempty"`
+	FwdStreams []*FwdStream `protobuf:"bytes,5,rep,name=fwdStreams,proto3" json:"fwdStreams,omitempty"`
+}
+
+func (x *ForwardingConfig) Reset() {
+	*x = ForwardingConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForwardingConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardingConfig) ProtoMessage() {}
+
+func (x *ForwardingConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardingConfig.ProtoReflect.Descriptor instead.
+func (*ForwardingConfig) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ForwardingConfig) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *ForwardingConfig) GetNumPorts() int32 {
+	if x != nil {
+		return x.NumPorts
+	}
+	return 0
+}
+
+func (x *ForwardingConfig) GetNumCores() int32 {
+	if x != nil {
+		return x.NumCores
+	}
+	return 0
+}
+
+func (x *ForwardingConfig) GetNumStreams() int32 {
+	if x != nil {
+		return x.NumStreams
+	}
+	return 0
+}
+
+func (x *ForwardingConfig) GetFwdStreams() []*FwdStream {
+	if x != nil {
+		return x.FwdStreams
+	}
+	return nil
+}
+
+type DpdkVersion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// as reported by testpmd or given with -dpdk-version, empty if unknown
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// the LTS release whose EAL and testpmd options are used, e.g. 20.11
+	Release     string `protobuf:"bytes,2,opt,name=release,proto3" json:"release,omitempty"`
+	TestpmdPath string `protobuf:"bytes,3,opt,name=testpmdPath,proto3" json:"testpmdPath,omitempty"`
+}
+
+func (x *DpdkVersion) Reset() {
+	*x = DpdkVersion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DpdkVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DpdkVersion) ProtoMessage() {}
+
+func (x *DpdkVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DpdkVersion.ProtoReflect.Descriptor instead.
+func (*DpdkVersion) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DpdkVersion) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *DpdkVersion) GetRelease() string {
+	if x != nil {
+		return x.Release
+	}
+	return ""
+}
+
+func (x *DpdkVersion) GetTestpmdPath() string {
+	if x != nil {
+		return x.TestpmdPath
+	}
+	return ""
+}
+
+var File_rpc_proto protoreflect.FileDescriptor
+
+var file_rpc_proto_rawDesc = []byte{
+	0x0a, 0x09, 0x72, 0x70, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x74, 0x65, 0x73,
+	0x74, 0x70, 0x6d, 0x64, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x23, 0x0a, 0x07, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x2c, 0x0a, 0x0a, 0x4d, 0x61, 0x63, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x63, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x22, 0x39, 0x0a, 0x08, 0x50, 0x6f, 0x72, 0x74, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x2d, 0x0a, 0x08, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x50, 0x6f, 0x72,
+	0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x22,
+	0x64, 0x0a, 0x08, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x70,
+	0x6f, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x70, 0x6f,
+	0x72, 0x74, 0x4e, 0x75, 0x6d, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x63, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x63, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x63, 0x69, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x63, 0x69, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x25, 0x0a, 0x03, 0x50, 0x63, 0x69, 0x12, 0x1e, 0x0a, 0x0a,
+	0x70, 0x63, 0x69, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x70, 0x63, 0x69, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x43, 0x0a, 0x07,
+	0x50, 0x65, 0x65, 0x72, 0x4d, 0x61, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x6f, 0x72, 0x74, 0x4e,
+	0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x70, 0x6f, 0x72, 0x74, 0x4e, 0x75,
+	0x6d, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x22, 0x36, 0x0a, 0x08, 0x50, 0x65, 0x65, 0x72, 0x4d, 0x61, 0x63, 0x73, 0x12, 0x2a, 0x0a,
+	0x07, 0x70, 0x65, 0x65, 0x72, 0x4d, 0x61, 0x63, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x4d, 0x61, 0x63,
+	0x52, 0x07, 0x70, 0x65, 0x65, 0x72, 0x4d, 0x61, 0x63, 0x22, 0x29, 0x0a, 0x07, 0x46, 0x77, 0x64,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x77, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x53,
+	0x74, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x77, 0x64, 0x49, 0x6e, 0x66,
+	0x6f, 0x53, 0x74, 0x72, 0x22, 0xc4, 0x02, 0x0a, 0x0c, 0x50, 0x6f, 0x72, 0x74, 0x46, 0x77, 0x64,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x6f, 0x72, 0x74, 0x4e, 0x75, 0x6d,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x70, 0x6f, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x12,
+	0x1c, 0x0a, 0x09, 0x72, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x09, 0x72, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x72, 0x78, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x72, 0x78, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x72,
+	0x78, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x72, 0x78,
+	0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x78, 0x50, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x78, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x78, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x78, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x07, 0x74, 0x78, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x72,
+	0x78, 0x42, 0x61, 0x64, 0x49, 0x70, 0x43, 0x73, 0x75, 0x6d, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0b, 0x72, 0x78, 0x42, 0x61, 0x64, 0x49, 0x70, 0x43, 0x73, 0x75, 0x6d, 0x12, 0x20, 0x0a,
+	0x0b, 0x72, 0x78, 0x42, 0x61, 0x64, 0x4c, 0x34, 0x43, 0x73, 0x75, 0x6d, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0b, 0x72, 0x78, 0x42, 0x61, 0x64, 0x4c, 0x34, 0x43, 0x73, 0x75, 0x6d, 0x12,
+	0x2a, 0x0a, 0x10, 0x72, 0x78, 0x42, 0x61, 0x64, 0x4f, 0x75, 0x74, 0x65, 0x72, 0x4c, 0x34, 0x43,
+	0x73, 0x75, 0x6d, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x72, 0x78, 0x42, 0x61, 0x64,
+	0x4f, 0x75, 0x74, 0x65, 0x72, 0x4c, 0x34, 0x43, 0x73, 0x75, 0x6d, 0x22, 0x78, 0x0a, 0x08, 0x46,
+	0x77, 0x64, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x33, 0x0a, 0x09, 0x70, 0x6f, 0x72, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x65, 0x73,
+	0x74, 0x70, 0x6d, 0x64, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x46, 0x77, 0x64, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x09, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x37, 0x0a, 0x0b,
+	0x61, 0x63, 0x63, 0x75, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x50, 0x6f, 0x72, 0x74,
+	0x46, 0x77, 0x64, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x75, 0x6d, 0x75,
+	0x6c, 0x61, 0x74, 0x65, 0x64, 0x22, 0x33, 0x0a, 0x11, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68,
+	0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4d, 0x73, 0x22, 0xc0, 0x01, 0x0a, 0x0e, 0x50,
+	0x6f, 0x72, 0x74, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x70, 0x6f, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07,
+	0x70, 0x6f, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x78, 0x50, 0x70, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x72, 0x78, 0x50, 0x70, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x78, 0x50, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x74, 0x78,
+	0x50, 0x70, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x78, 0x42, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x05, 0x72, 0x78, 0x42, 0x70, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x78, 0x42,
+	0x70, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x74, 0x78, 0x42, 0x70, 0x73, 0x12,
+	0x1c, 0x0a, 0x09, 0x72, 0x78, 0x44, 0x72, 0x6f, 0x70, 0x50, 0x70, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x09, 0x72, 0x78, 0x44, 0x72, 0x6f, 0x70, 0x50, 0x70, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x74, 0x78, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x50, 0x70, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0a, 0x74, 0x78, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x50, 0x70, 0x73, 0x22, 0x8f, 0x01,
+	0x0a, 0x0a, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x12, 0x20, 0x0a, 0x0b,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4d, 0x73, 0x12, 0x1e,
+	0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4d, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4d, 0x73, 0x12, 0x3f,
+	0x0a, 0x0e, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64,
+	0x2e, 0x50, 0x6f, 0x72, 0x74, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x52,
+	0x0e, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x22,
+	0x4e, 0x0a, 0x0e, 0x54, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x12, 0x26, 0x0a, 0x0e, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x65, 0x6e, 0x67,
+	0x74, 0x68, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0e, 0x73, 0x65, 0x67, 0x6d, 0x65,
+	0x6e, 0x74, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x75, 0x72,
+	0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x62, 0x75, 0x72, 0x73, 0x74, 0x22,
+	0xae, 0x01, 0x0a, 0x0e, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x4d, 0x6f,
+	0x64, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x12, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x46, 0x77, 0x64,
+	0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x52, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x35,
+	0x0a, 0x08, 0x74, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x54, 0x78, 0x50, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x48, 0x00, 0x52, 0x08, 0x74, 0x78, 0x50,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x2f, 0x0a, 0x08, 0x70, 0x65, 0x65, 0x72, 0x4d, 0x61, 0x63,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d,
+	0x64, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x4d, 0x61, 0x63, 0x73, 0x48, 0x00, 0x52, 0x08, 0x70, 0x65,
+	0x65, 0x72, 0x4d, 0x61, 0x63, 0x73, 0x42, 0x08, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73,
+	0x22, 0x65, 0x0a, 0x05, 0x4c, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x75, 0x6d,
+	0x61, 0x4e, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6e, 0x75, 0x6d,
+	0x61, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x69, 0x62, 0x6c, 0x69, 0x6e, 0x67,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x08, 0x73, 0x69, 0x62, 0x6c, 0x69, 0x6e, 0x67,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x22, 0xa4, 0x01, 0x0a, 0x08, 0x43, 0x6f, 0x72, 0x65,
+	0x50, 0x6c, 0x61, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x24, 0x0a, 0x0d,
+	0x70, 0x6f, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x61, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x05, 0x52, 0x0d, 0x70, 0x6f, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x61, 0x4e, 0x6f, 0x64,
+	0x65, 0x73, 0x12, 0x2c, 0x0a, 0x09, 0x6d, 0x61, 0x69, 0x6e, 0x4c, 0x63, 0x6f, 0x72, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e,
+	0x4c, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x09, 0x6d, 0x61, 0x69, 0x6e, 0x4c, 0x63, 0x6f, 0x72, 0x65,
+	0x12, 0x2c, 0x0a, 0x09, 0x70, 0x6d, 0x64, 0x4c, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x4c, 0x63,
+	0x6f, 0x72, 0x65, 0x52, 0x09, 0x70, 0x6d, 0x64, 0x4c, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x22, 0xc1,
+	0x01, 0x0a, 0x09, 0x46, 0x77, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x63, 0x6f,
+	0x72, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x63, 0x6f, 0x72, 0x65, 0x53, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6c, 0x63, 0x6f, 0x72, 0x65, 0x53, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x78, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x72, 0x78, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x72, 0x78, 0x51, 0x75, 0x65, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x72,
+	0x78, 0x51, 0x75, 0x65, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x78, 0x50, 0x6f, 0x72, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x74, 0x78, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x74, 0x78, 0x51, 0x75, 0x65, 0x75, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x74, 0x78, 0x51, 0x75, 0x65, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x65, 0x65, 0x72,
+	0x4d, 0x61, 0x63, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x65, 0x65, 0x72, 0x4d,
+	0x61, 0x63, 0x22, 0xb2, 0x01, 0x0a, 0x10, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e,
+	0x75, 0x6d, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6e,
+	0x75, 0x6d, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x75, 0x6d, 0x43, 0x6f,
+	0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6e, 0x75, 0x6d, 0x43, 0x6f,
+	0x72, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x75, 0x6d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6e, 0x75, 0x6d, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x73, 0x12, 0x32, 0x0a, 0x0a, 0x66, 0x77, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d,
+	0x64, 0x2e, 0x46, 0x77, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x0a, 0x66, 0x77, 0x64,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x22, 0x63, 0x0a, 0x0b, 0x44, 0x70, 0x64, 0x6b, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x65,
+	0x73, 0x74, 0x70, 0x6d, 0x64, 0x50, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x50, 0x61, 0x74, 0x68, 0x2a, 0xaa, 0x01, 0x0a,
+	0x09, 0x46, 0x77, 0x64, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x57,
+	0x44, 0x5f, 0x49, 0x4f, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x46, 0x57, 0x44, 0x5f, 0x4d, 0x41,
+	0x43, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x46, 0x57, 0x44, 0x5f, 0x4d, 0x41, 0x43, 0x53, 0x57,
+	0x41, 0x50, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x46, 0x57, 0x44, 0x5f, 0x46, 0x4c, 0x4f, 0x57,
+	0x47, 0x45, 0x4e, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x46, 0x57, 0x44, 0x5f, 0x52, 0x58, 0x4f,
+	0x4e, 0x4c, 0x59, 0x10, 0x04, 0x12, 0x0e, 0x0a, 0x0a, 0x46, 0x57, 0x44, 0x5f, 0x54, 0x58, 0x4f,
+	0x4e, 0x4c, 0x59, 0x10, 0x05, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x57, 0x44, 0x5f, 0x43, 0x53, 0x55,
+	0x4d, 0x10, 0x06, 0x12, 0x10, 0x0a, 0x0c, 0x46, 0x57, 0x44, 0x5f, 0x49, 0x43, 0x4d, 0x50, 0x45,
+	0x43, 0x48, 0x4f, 0x10, 0x07, 0x12, 0x13, 0x0a, 0x0f, 0x46, 0x57, 0x44, 0x5f, 0x35, 0x54, 0x55,
+	0x50, 0x4c, 0x45, 0x5f, 0x53, 0x57, 0x41, 0x50, 0x10, 0x08, 0x12, 0x0d, 0x0a, 0x09, 0x46, 0x57,
+	0x44, 0x5f, 0x4e, 0x4f, 0x49, 0x53, 0x59, 0x10, 0x09, 0x32, 0xb6, 0x06, 0x0a, 0x07, 0x74, 0x65,
+	0x73, 0x74, 0x70, 0x6d, 0x64, 0x12, 0x32, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x63, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x0c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64,
+	0x2e, 0x50, 0x63, 0x69, 0x1a, 0x13, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x4d,
+	0x61, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2e, 0x0a, 0x0b, 0x47, 0x65, 0x74,
+	0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70,
+	0x6d, 0x64, 0x2e, 0x50, 0x63, 0x69, 0x1a, 0x11, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64,
+	0x2e, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x36, 0x0a, 0x09, 0x4c, 0x69, 0x73,
+	0x74, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11,
+	0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x4c, 0x69, 0x73,
+	0x74, 0x12, 0x34, 0x0a, 0x08, 0x49, 0x63, 0x6d, 0x70, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e,
+	0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x32, 0x0a, 0x06, 0x49, 0x6f, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x74, 0x65, 0x73, 0x74,
+	0x70, 0x6d, 0x64, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x4d,
+	0x61, 0x63, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x11, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64,
+	0x2e, 0x50, 0x65, 0x65, 0x72, 0x4d, 0x61, 0x63, 0x73, 0x1a, 0x10, 0x2e, 0x74, 0x65, 0x73, 0x74,
+	0x70, 0x6d, 0x64, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x36, 0x0a, 0x0a, 0x47,
+	0x65, 0x74, 0x46, 0x77, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x10, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x46, 0x77, 0x64, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x38, 0x0a, 0x0c, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x46, 0x77, 0x64, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x74, 0x65,
+	0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x38, 0x0a,
+	0x0b, 0x47, 0x65, 0x74, 0x46, 0x77, 0x64, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x46,
+	0x77, 0x64, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x45, 0x0a, 0x10, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x12, 0x1a, 0x2e, 0x74, 0x65,
+	0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d,
+	0x64, 0x2e, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x30, 0x01, 0x12, 0x3e,
+	0x0a, 0x11, 0x53, 0x65, 0x74, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x4d,
+	0x6f, 0x64, 0x65, 0x12, 0x17, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x46, 0x6f,
+	0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x1a, 0x10, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x38,
+	0x0a, 0x0b, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x72, 0x65, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e,
+	0x43, 0x6f, 0x72, 0x65, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x48, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x46,
+	0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x19, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d,
+	0x64, 0x2e, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x12, 0x3e, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x44, 0x70, 0x64, 0x6b, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x14, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x44, 0x70, 0x64, 0x6b, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x42, 0x45, 0x5a, 0x43, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x72, 0x65, 0x64, 0x68, 0x61, 0x74, 0x2d, 0x6e, 0x66, 0x76, 0x70, 0x65, 0x2f, 0x63, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2d, 0x70, 0x65, 0x72, 0x66, 0x2d, 0x74, 0x6f, 0x6f,
+	0x6c, 0x73, 0x2f, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x6f, 0x6e, 0x65, 0x2d, 0x74, 0x65,
+	0x73, 0x74, 0x70, 0x6d, 0x64, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_rpc_proto_rawDescOnce sync.Once
+	file_rpc_proto_rawDescData = file_rpc_proto_rawDesc
+)
+
+func file_rpc_proto_rawDescGZIP() []byte {
+	file_rpc_proto_rawDescOnce.Do(func() {
+		file_rpc_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_proto_rawDescData)
+	})
+	return file_rpc_proto_rawDescData
+}
+
+var file_rpc_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_rpc_proto_goTypes = []interface{}{
+	(FwdEngine)(0),            // 0: testpmd.FwdEngine
+	(*Success)(nil),           // 1: testpmd.Success
+	(*MacAddress)(nil),        // 2: testpmd.MacAddress
+	(*PortList)(nil),          // 3: testpmd.PortList
+	(*PortInfo)(nil),          // 4: testpmd.PortInfo
+	(*Pci)(nil),               // 5: testpmd.Pci
+	(*PeerMac)(nil),           // 6: testpmd.PeerMac
+	(*PeerMacs)(nil),          // 7: testpmd.PeerMacs
+	(*FwdInfo)(nil),           // 8: testpmd.FwdInfo
+	(*PortFwdStats)(nil),      // 9: testpmd.PortFwdStats
+	(*FwdStats)(nil),          // 10: testpmd.FwdStats
+	(*ThroughputRequest)(nil), // 11: testpmd.ThroughputRequest
+	(*PortThroughput)(nil),    // 12: testpmd.PortThroughput
+	(*Throughput)(nil),        // 13: testpmd.Throughput
+	(*TxPacketParams)(nil),    // 14: testpmd.TxPacketParams
+	(*ForwardingMode)(nil),    // 15: testpmd.ForwardingMode
+	(*Lcore)(nil),             // 16: testpmd.Lcore
+	(*CorePlan)(nil),          // 17: testpmd.CorePlan
+	(*FwdStream)(nil),         // 18: testpmd.FwdStream
+	(*ForwardingConfig)(nil),  // 19: testpmd.ForwardingConfig
+	(*DpdkVersion)(nil),       // 20: testpmd.DpdkVersion
+	(*empty.Empty)(nil),       // 21: google.protobuf.Empty
+}
+var file_rpc_proto_depIdxs = []int32{
+	4,  // 0: testpmd.PortList.portInfo:type_name -> testpmd.PortInfo
+	6,  // 1: testpmd.PeerMacs.peerMac:type_name -> testpmd.PeerMac
+	9,  // 2: testpmd.FwdStats.portStats:type_name -> testpmd.PortFwdStats
+	9,  // 3: testpmd.FwdStats.accumulated:type_name -> testpmd.PortFwdStats
+	12, // 4: testpmd.Throughput.portThroughput:type_name -> testpmd.PortThroughput
+	0,  // 5: testpmd.ForwardingMode.engine:type_name -> testpmd.FwdEngine
+	14, // 6: testpmd.ForwardingMode.txPacket:type_name -> testpmd.TxPacketParams
+	7,  // 7: testpmd.ForwardingMode.peerMacs:type_name -> testpmd.PeerMacs
+	16, // 8: testpmd.CorePlan.mainLcore:type_name -> testpmd.Lcore
+	16, // 9: testpmd.CorePlan.pmdLcores:type_name -> testpmd.Lcore
+	18, // 10: testpmd.ForwardingConfig.fwdStreams:type_name -> testpmd.FwdStream
+	5,  // 11: testpmd.testpmd.GetMacAddress:input_type -> testpmd.Pci
+	5,  // 12: testpmd.testpmd.GetPortInfo:input_type -> testpmd.Pci
+	21, // 13: testpmd.testpmd.ListPorts:input_type -> google.protobuf.Empty
+	21, // 14: testpmd.testpmd.IcmpMode:input_type -> google.protobuf.Empty
+	21, // 15: testpmd.testpmd.IoMode:input_type -> google.protobuf.Empty
+	7,  // 16: testpmd.testpmd.MacMode:input_type -> testpmd.PeerMacs
+	21, // 17: testpmd.testpmd.GetFwdInfo:input_type -> google.protobuf.Empty
+	21, // 18: testpmd.testpmd.ClearFwdInfo:input_type -> google.protobuf.Empty
+	21, // 19: testpmd.testpmd.GetFwdStats:input_type -> google.protobuf.Empty
+	11, // 20: testpmd.testpmd.StreamThroughput:input_type -> testpmd.ThroughputRequest
+	15, // 21: testpmd.testpmd.SetForwardingMode:input_type -> testpmd.ForwardingMode
+	21, // 22: testpmd.testpmd.GetCorePlan:input_type -> google.protobuf.Empty
+	21, // 23: testpmd.testpmd.GetForwardingConfig:input_type -> google.protobuf.Empty
+	21, // 24: testpmd.testpmd.GetDpdkVersion:input_type -> google.protobuf.Empty
+	2,  // 25: testpmd.testpmd.GetMacAddress:output_type -> testpmd.MacAddress
+	4,  // 26: testpmd.testpmd.GetPortInfo:output_type -> testpmd.PortInfo
+	3,  // 27: testpmd.testpmd.ListPorts:output_type -> testpmd.PortList
+	1,  // 28: testpmd.testpmd.IcmpMode:output_type -> testpmd.Success
+	1,  // 29: testpmd.testpmd.IoMode:output_type -> testpmd.Success
+	1,  // 30: testpmd.testpmd.MacMode:output_type -> testpmd.Success
+	8,  // 31: testpmd.testpmd.GetFwdInfo:output_type -> testpmd.FwdInfo
+	1,  // 32: testpmd.testpmd.ClearFwdInfo:output_type -> testpmd.Success
+	10, // 33: testpmd.testpmd.GetFwdStats:output_type -> testpmd.FwdStats
+	13, // 34: testpmd.testpmd.StreamThroughput:output_type -> testpmd.Throughput
+	1,  // 35: testpmd.testpmd.SetForwardingMode:output_type -> testpmd.Success
+	17, // 36: testpmd.testpmd.GetCorePlan:output_type -> testpmd.CorePlan
+	19, // 37: testpmd.testpmd.GetForwardingConfig:output_type -> testpmd.ForwardingConfig
+	20, // 38: testpmd.testpmd.GetDpdkVersion:output_type -> testpmd.DpdkVersion
+	25, // [25:39] is the sub-list for method output_type
+	11, // [11:25] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_rpc_proto_init() }
+func file_rpc_proto_init() {
+	if File_rpc_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Success); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MacAddress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PortList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PortInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pci); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
 		}
 		file_rpc_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PeerMac); i {
@@ -647,19 +1824,168 @@ func file_rpc_proto_init() {
 				return nil
 			}
 		}
+		file_rpc_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PortFwdStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FwdStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ThroughputRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PortThroughput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Throughput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxPacketParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ForwardingMode); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Lcore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CorePlan); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FwdStream); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ForwardingConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DpdkVersion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_rpc_proto_msgTypes[14].OneofWrappers = []interface{}{
+		(*ForwardingMode_TxPacket)(nil),
+		(*ForwardingMode_PeerMacs)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_rpc_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      1,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_rpc_proto_goTypes,
 		DependencyIndexes: file_rpc_proto_depIdxs,
+		EnumInfos:         file_rpc_proto_enumTypes,
 		MessageInfos:      file_rpc_proto_msgTypes,
 	}.Build()
 	File_rpc_proto = out.File
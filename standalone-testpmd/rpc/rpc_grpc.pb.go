@@ -26,6 +26,12 @@ type TestpmdClient interface {
 	MacMode(ctx context.Context, in *PeerMacs, opts ...grpc.CallOption) (*Success, error)
 	GetFwdInfo(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*FwdInfo, error)
 	ClearFwdInfo(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Success, error)
+	GetFwdStats(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*FwdStats, error)
+	StreamThroughput(ctx context.Context, in *ThroughputRequest, opts ...grpc.CallOption) (Testpmd_StreamThroughputClient, error)
+	SetForwardingMode(ctx context.Context, in *ForwardingMode, opts ...grpc.CallOption) (*Success, error)
+	GetCorePlan(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CorePlan, error)
+	GetForwardingConfig(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ForwardingConfig, error)
+	GetDpdkVersion(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*DpdkVersion, error)
 }
 
 type testpmdClient struct {
@@ -108,6 +114,83 @@ func (c *testpmdClient) ClearFwdInfo(ctx context.Context, in *empty.Empty, opts
 	return out, nil
 }
 
+func (c *testpmdClient) GetFwdStats(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*FwdStats, error) {
+	out := new(FwdStats)
+	err := c.cc.Invoke(ctx, "/testpmd.testpmd/GetFwdStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testpmdClient) StreamThroughput(ctx context.Context, in *ThroughputRequest, opts ...grpc.CallOption) (Testpmd_StreamThroughputClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Testpmd_serviceDesc.Streams[0], "/testpmd.testpmd/StreamThroughput", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &testpmdStreamThroughputClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Testpmd_StreamThroughputClient interface {
+	Recv() (*Throughput, error)
+	grpc.ClientStream
+}
+
+type testpmdStreamThroughputClient struct {
+	grpc.ClientStream
+}
+
+func (x *testpmdStreamThroughputClient) Recv() (*Throughput, error) {
+	m := new(Throughput)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *testpmdClient) SetForwardingMode(ctx context.Context, in *ForwardingMode, opts ...grpc.CallOption) (*Success, error) {
+	out := new(Success)
+	err := c.cc.Invoke(ctx, "/testpmd.testpmd/SetForwardingMode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testpmdClient) GetCorePlan(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CorePlan, error) {
+	out := new(CorePlan)
+	err := c.cc.Invoke(ctx, "/testpmd.testpmd/GetCorePlan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testpmdClient) GetForwardingConfig(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ForwardingConfig, error) {
+	out := new(ForwardingConfig)
+	err := c.cc.Invoke(ctx, "/testpmd.testpmd/GetForwardingConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testpmdClient) GetDpdkVersion(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*DpdkVersion, error) {
+	out := new(DpdkVersion)
+	err := c.cc.Invoke(ctx, "/testpmd.testpmd/GetDpdkVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TestpmdServer is the server API for Testpmd service.
 // All implementations must embed UnimplementedTestpmdServer
 // for forward compatibility
@@ -120,6 +203,12 @@ type TestpmdServer interface {
 	MacMode(context.Context, *PeerMacs) (*Success, error)
 	GetFwdInfo(context.Context, *empty.Empty) (*FwdInfo, error)
 	ClearFwdInfo(context.Context, *empty.Empty) (*Success, error)
+	GetFwdStats(context.Context, *empty.Empty) (*FwdStats, error)
+	StreamThroughput(*ThroughputRequest, Testpmd_StreamThroughputServer) error
+	SetForwardingMode(context.Context, *ForwardingMode) (*Success, error)
+	GetCorePlan(context.Context, *empty.Empty) (*CorePlan, error)
+	GetForwardingConfig(context.Context, *empty.Empty) (*ForwardingConfig, error)
+	GetDpdkVersion(context.Context, *empty.Empty) (*DpdkVersion, error)
 	mustEmbedUnimplementedTestpmdServer()
 }
 
@@ -151,6 +240,24 @@ func (UnimplementedTestpmdServer) GetFwdInfo(context.Context, *empty.Empty) (*Fw
 func (UnimplementedTestpmdServer) ClearFwdInfo(context.Context, *empty.Empty) (*Success, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ClearFwdInfo not implemented")
 }
+func (UnimplementedTestpmdServer) GetFwdStats(context.Context, *empty.Empty) (*FwdStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFwdStats not implemented")
+}
+func (UnimplementedTestpmdServer) StreamThroughput(*ThroughputRequest, Testpmd_StreamThroughputServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamThroughput not implemented")
+}
+func (UnimplementedTestpmdServer) SetForwardingMode(context.Context, *ForwardingMode) (*Success, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetForwardingMode not implemented")
+}
+func (UnimplementedTestpmdServer) GetCorePlan(context.Context, *empty.Empty) (*CorePlan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCorePlan not implemented")
+}
+func (UnimplementedTestpmdServer) GetForwardingConfig(context.Context, *empty.Empty) (*ForwardingConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetForwardingConfig not implemented")
+}
+func (UnimplementedTestpmdServer) GetDpdkVersion(context.Context, *empty.Empty) (*DpdkVersion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDpdkVersion not implemented")
+}
 func (UnimplementedTestpmdServer) mustEmbedUnimplementedTestpmdServer() {}
 
 // UnsafeTestpmdServer may be embedded to opt out of forward compatibility for this service.
@@ -308,6 +415,117 @@ func _Testpmd_ClearFwdInfo_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Testpmd_GetFwdStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestpmdServer).GetFwdStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/testpmd.testpmd/GetFwdStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestpmdServer).GetFwdStats(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testpmd_StreamThroughput_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ThroughputRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TestpmdServer).StreamThroughput(m, &testpmdStreamThroughputServer{stream})
+}
+
+type Testpmd_StreamThroughputServer interface {
+	Send(*Throughput) error
+	grpc.ServerStream
+}
+
+type testpmdStreamThroughputServer struct {
+	grpc.ServerStream
+}
+
+func (x *testpmdStreamThroughputServer) Send(m *Throughput) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Testpmd_SetForwardingMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForwardingMode)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestpmdServer).SetForwardingMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/testpmd.testpmd/SetForwardingMode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestpmdServer).SetForwardingMode(ctx, req.(*ForwardingMode))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testpmd_GetCorePlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestpmdServer).GetCorePlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/testpmd.testpmd/GetCorePlan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestpmdServer).GetCorePlan(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testpmd_GetForwardingConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestpmdServer).GetForwardingConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/testpmd.testpmd/GetForwardingConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestpmdServer).GetForwardingConfig(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testpmd_GetDpdkVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestpmdServer).GetDpdkVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/testpmd.testpmd/GetDpdkVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestpmdServer).GetDpdkVersion(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Testpmd_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "testpmd.testpmd",
 	HandlerType: (*TestpmdServer)(nil),
@@ -344,7 +562,33 @@ var _Testpmd_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ClearFwdInfo",
 			Handler:    _Testpmd_ClearFwdInfo_Handler,
 		},
+		{
+			MethodName: "GetFwdStats",
+			Handler:    _Testpmd_GetFwdStats_Handler,
+		},
+		{
+			MethodName: "SetForwardingMode",
+			Handler:    _Testpmd_SetForwardingMode_Handler,
+		},
+		{
+			MethodName: "GetCorePlan",
+			Handler:    _Testpmd_GetCorePlan_Handler,
+		},
+		{
+			MethodName: "GetForwardingConfig",
+			Handler:    _Testpmd_GetForwardingConfig_Handler,
+		},
+		{
+			MethodName: "GetDpdkVersion",
+			Handler:    _Testpmd_GetDpdkVersion_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamThroughput",
+			Handler:       _Testpmd_StreamThroughput_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpc.proto",
 }
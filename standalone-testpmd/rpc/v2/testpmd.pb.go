@@ -0,0 +1,2297 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.5.0
+// source: v2/testpmd.proto
+
+// the messages of v2 are resources of the wrapper rather than the answer of one call,
+// testpmd.testpmd of rpc.proto is kept as it is for the clients generated from it
+
+package v2
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type Engine int32
+
+const (
+	Engine_ENGINE_UNSPECIFIED Engine = 0
+	Engine_ENGINE_IO          Engine = 1
+	Engine_ENGINE_MAC         Engine = 2
+	Engine_ENGINE_MACSWAP     Engine = 3
+	Engine_ENGINE_FLOWGEN     Engine = 4
+	Engine_ENGINE_RXONLY      Engine = 5
+	Engine_ENGINE_TXONLY      Engine = 6
+	Engine_ENGINE_CSUM        Engine = 7
+	Engine_ENGINE_ICMPECHO    Engine = 8
+	Engine_ENGINE_5TSWAP      Engine = 9
+	// the buffer and lookup sizes are set with the -noisy-* flags of the wrapper
+	Engine_ENGINE_NOISY Engine = 10
+)
+
+// Enum value maps for Engine.
+var (
+	Engine_name = map[int32]string{
+		0:  "ENGINE_UNSPECIFIED",
+		1:  "ENGINE_IO",
+		2:  "ENGINE_MAC",
+		3:  "ENGINE_MACSWAP",
+		4:  "ENGINE_FLOWGEN",
+		5:  "ENGINE_RXONLY",
+		6:  "ENGINE_TXONLY",
+		7:  "ENGINE_CSUM",
+		8:  "ENGINE_ICMPECHO",
+		9:  "ENGINE_5TSWAP",
+		10: "ENGINE_NOISY",
+	}
+	Engine_value = map[string]int32{
+		"ENGINE_UNSPECIFIED": 0,
+		"ENGINE_IO":          1,
+		"ENGINE_MAC":         2,
+		"ENGINE_MACSWAP":     3,
+		"ENGINE_FLOWGEN":     4,
+		"ENGINE_RXONLY":      5,
+		"ENGINE_TXONLY":      6,
+		"ENGINE_CSUM":        7,
+		"ENGINE_ICMPECHO":    8,
+		"ENGINE_5TSWAP":      9,
+		"ENGINE_NOISY":       10,
+	}
+)
+
+func (x Engine) Enum() *Engine {
+	p := new(Engine)
+	*p = x
+	return p
+}
+
+func (x Engine) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Engine) Descriptor() protoreflect.EnumDescriptor {
+	return file_v2_testpmd_proto_enumTypes[0].Descriptor()
+}
+
+func (Engine) Type() protoreflect.EnumType {
+	return &file_v2_testpmd_proto_enumTypes[0]
+}
+
+func (x Engine) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Engine.Descriptor instead.
+func (Engine) EnumDescriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{0}
+}
+
+type Port struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// pci address or vdev name
+	Name       string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	MacAddress string `protobuf:"bytes,3,opt,name=mac_address,json=macAddress,proto3" json:"mac_address,omitempty"`
+}
+
+func (x *Port) Reset() {
+	*x = Port{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Port) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Port) ProtoMessage() {}
+
+func (x *Port) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Port.ProtoReflect.Descriptor instead.
+func (*Port) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Port) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Port) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Port) GetMacAddress() string {
+	if x != nil {
+		return x.MacAddress
+	}
+	return ""
+}
+
+type ListPortsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListPortsRequest) Reset() {
+	*x = ListPortsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPortsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPortsRequest) ProtoMessage() {}
+
+func (x *ListPortsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPortsRequest.ProtoReflect.Descriptor instead.
+func (*ListPortsRequest) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{1}
+}
+
+type ListPortsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ports []*Port `protobuf:"bytes,1,rep,name=ports,proto3" json:"ports,omitempty"`
+}
+
+func (x *ListPortsResponse) Reset() {
+	*x = ListPortsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPortsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPortsResponse) ProtoMessage() {}
+
+func (x *ListPortsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPortsResponse.ProtoReflect.Descriptor instead.
+func (*ListPortsResponse) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListPortsResponse) GetPorts() []*Port {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+type GetPortRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// pci address or vdev name
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *GetPortRequest) Reset() {
+	*x = GetPortRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPortRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPortRequest) ProtoMessage() {}
+
+func (x *GetPortRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPortRequest.ProtoReflect.Descriptor instead.
+func (*GetPortRequest) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetPortRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// packet layout of the txonly and flowgen engines
+type TxPacket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// length of each segment of the generated packets, "set txpkts"
+	SegmentLengths []uint32 `protobuf:"varint,1,rep,packed,name=segment_lengths,json=segmentLengths,proto3" json:"segment_lengths,omitempty"`
+	// packets per burst, "set burst", unchanged if 0
+	Burst uint32 `protobuf:"varint,2,opt,name=burst,proto3" json:"burst,omitempty"`
+}
+
+func (x *TxPacket) Reset() {
+	*x = TxPacket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TxPacket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxPacket) ProtoMessage() {}
+
+func (x *TxPacket) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxPacket.ProtoReflect.Descriptor instead.
+func (*TxPacket) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TxPacket) GetSegmentLengths() []uint32 {
+	if x != nil {
+		return x.SegmentLengths
+	}
+	return nil
+}
+
+func (x *TxPacket) GetBurst() uint32 {
+	if x != nil {
+		return x.Burst
+	}
+	return 0
+}
+
+type PeerMac struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PortId     int32  `protobuf:"varint,1,opt,name=port_id,json=portId,proto3" json:"port_id,omitempty"`
+	MacAddress string `protobuf:"bytes,2,opt,name=mac_address,json=macAddress,proto3" json:"mac_address,omitempty"`
+}
+
+func (x *PeerMac) Reset() {
+	*x = PeerMac{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerMac) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerMac) ProtoMessage() {}
+
+func (x *PeerMac) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerMac.ProtoReflect.Descriptor instead.
+func (*PeerMac) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PeerMac) GetPortId() int32 {
+	if x != nil {
+		return x.PortId
+	}
+	return 0
+}
+
+func (x *PeerMac) GetMacAddress() string {
+	if x != nil {
+		return x.MacAddress
+	}
+	return ""
+}
+
+// a forwarding stream of "show config fwd"
+type Stream struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lcore       int32  `protobuf:"varint,1,opt,name=lcore,proto3" json:"lcore,omitempty"`
+	LcoreSocket int32  `protobuf:"varint,2,opt,name=lcore_socket,json=lcoreSocket,proto3" json:"lcore_socket,omitempty"`
+	RxPort      int32  `protobuf:"varint,3,opt,name=rx_port,json=rxPort,proto3" json:"rx_port,omitempty"`
+	RxQueue     int32  `protobuf:"varint,4,opt,name=rx_queue,json=rxQueue,proto3" json:"rx_queue,omitempty"`
+	TxPort      int32  `protobuf:"varint,5,opt,name=tx_port,json=txPort,proto3" json:"tx_port,omitempty"`
+	TxQueue     int32  `protobuf:"varint,6,opt,name=tx_queue,json=txQueue,proto3" json:"tx_queue,omitempty"`
+	PeerMac     string `protobuf:"bytes,7,opt,name=peer_mac,json=peerMac,proto3" json:"peer_mac,omitempty"`
+}
+
+func (x *Stream) Reset() {
+	*x = Stream{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Stream) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stream) ProtoMessage() {}
+
+func (x *Stream) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stream.ProtoReflect.Descriptor instead.
+func (*Stream) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Stream) GetLcore() int32 {
+	if x != nil {
+		return x.Lcore
+	}
+	return 0
+}
+
+func (x *Stream) GetLcoreSocket() int32 {
+	if x != nil {
+		return x.LcoreSocket
+	}
+	return 0
+}
+
+func (x *Stream) GetRxPort() int32 {
+	if x != nil {
+		return x.RxPort
+	}
+	return 0
+}
+
+func (x *Stream) GetRxQueue() int32 {
+	if x != nil {
+		return x.RxQueue
+	}
+	return 0
+}
+
+func (x *Stream) GetTxPort() int32 {
+	if x != nil {
+		return x.TxPort
+	}
+	return 0
+}
+
+func (x *Stream) GetTxQueue() int32 {
+	if x != nil {
+		return x.TxQueue
+	}
+	return 0
+}
+
+func (x *Stream) GetPeerMac() string {
+	if x != nil {
+		return x.PeerMac
+	}
+	return ""
+}
+
+type ForwardingConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Engine Engine `protobuf:"varint,1,opt,name=engine,proto3,enum=testpmd.v2.Engine" json:"engine,omitempty"`
+	// testpmd name of the mode, also set for the modes without engine
+	Mode string `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	// txonly and flowgen only, not returned
+	TxPacket *TxPacket `protobuf:"bytes,3,opt,name=tx_packet,json=txPacket,proto3" json:"tx_packet,omitempty"`
+	// mac only, returned from the streams
+	PeerMacs []*PeerMac `protobuf:"bytes,4,rep,name=peer_macs,json=peerMacs,proto3" json:"peer_macs,omitempty"`
+	// the fields below are output only
+	Running  bool      `protobuf:"varint,5,opt,name=running,proto3" json:"running,omitempty"`
+	NumCores int32     `protobuf:"varint,6,opt,name=num_cores,json=numCores,proto3" json:"num_cores,omitempty"`
+	Streams  []*Stream `protobuf:"bytes,7,rep,name=streams,proto3" json:"streams,omitempty"`
+}
+
+func (x *ForwardingConfig) Reset() {
+	*x = ForwardingConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForwardingConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardingConfig) ProtoMessage() {}
+
+func (x *ForwardingConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardingConfig.ProtoReflect.Descriptor instead.
+func (*ForwardingConfig) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ForwardingConfig) GetEngine() Engine {
+	if x != nil {
+		return x.Engine
+	}
+	return Engine_ENGINE_UNSPECIFIED
+}
+
+func (x *ForwardingConfig) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *ForwardingConfig) GetTxPacket() *TxPacket {
+	if x != nil {
+		return x.TxPacket
+	}
+	return nil
+}
+
+func (x *ForwardingConfig) GetPeerMacs() []*PeerMac {
+	if x != nil {
+		return x.PeerMacs
+	}
+	return nil
+}
+
+func (x *ForwardingConfig) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *ForwardingConfig) GetNumCores() int32 {
+	if x != nil {
+		return x.NumCores
+	}
+	return 0
+}
+
+func (x *ForwardingConfig) GetStreams() []*Stream {
+	if x != nil {
+		return x.Streams
+	}
+	return nil
+}
+
+type GetForwardingConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetForwardingConfigRequest) Reset() {
+	*x = GetForwardingConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetForwardingConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetForwardingConfigRequest) ProtoMessage() {}
+
+func (x *GetForwardingConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetForwardingConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetForwardingConfigRequest) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{8}
+}
+
+type StartForwardingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StartForwardingRequest) Reset() {
+	*x = StartForwardingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartForwardingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartForwardingRequest) ProtoMessage() {}
+
+func (x *StartForwardingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartForwardingRequest.ProtoReflect.Descriptor instead.
+func (*StartForwardingRequest) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{9}
+}
+
+type StopForwardingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StopForwardingRequest) Reset() {
+	*x = StopForwardingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StopForwardingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopForwardingRequest) ProtoMessage() {}
+
+func (x *StopForwardingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopForwardingRequest.ProtoReflect.Descriptor instead.
+func (*StopForwardingRequest) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{10}
+}
+
+type PortStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PortId           int32  `protobuf:"varint,1,opt,name=port_id,json=portId,proto3" json:"port_id,omitempty"`
+	RxPackets        uint64 `protobuf:"varint,2,opt,name=rx_packets,json=rxPackets,proto3" json:"rx_packets,omitempty"`
+	RxDropped        uint64 `protobuf:"varint,3,opt,name=rx_dropped,json=rxDropped,proto3" json:"rx_dropped,omitempty"`
+	RxTotal          uint64 `protobuf:"varint,4,opt,name=rx_total,json=rxTotal,proto3" json:"rx_total,omitempty"`
+	TxPackets        uint64 `protobuf:"varint,5,opt,name=tx_packets,json=txPackets,proto3" json:"tx_packets,omitempty"`
+	TxDropped        uint64 `protobuf:"varint,6,opt,name=tx_dropped,json=txDropped,proto3" json:"tx_dropped,omitempty"`
+	TxTotal          uint64 `protobuf:"varint,7,opt,name=tx_total,json=txTotal,proto3" json:"tx_total,omitempty"`
+	RxBadIpCsum      uint64 `protobuf:"varint,8,opt,name=rx_bad_ip_csum,json=rxBadIpCsum,proto3" json:"rx_bad_ip_csum,omitempty"`
+	RxBadL4Csum      uint64 `protobuf:"varint,9,opt,name=rx_bad_l4_csum,json=rxBadL4Csum,proto3" json:"rx_bad_l4_csum,omitempty"`
+	RxBadOuterL4Csum uint64 `protobuf:"varint,10,opt,name=rx_bad_outer_l4_csum,json=rxBadOuterL4Csum,proto3" json:"rx_bad_outer_l4_csum,omitempty"`
+}
+
+func (x *PortStats) Reset() {
+	*x = PortStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PortStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortStats) ProtoMessage() {}
+
+func (x *PortStats) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortStats.ProtoReflect.Descriptor instead.
+func (*PortStats) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PortStats) GetPortId() int32 {
+	if x != nil {
+		return x.PortId
+	}
+	return 0
+}
+
+func (x *PortStats) GetRxPackets() uint64 {
+	if x != nil {
+		return x.RxPackets
+	}
+	return 0
+}
+
+func (x *PortStats) GetRxDropped() uint64 {
+	if x != nil {
+		return x.RxDropped
+	}
+	return 0
+}
+
+func (x *PortStats) GetRxTotal() uint64 {
+	if x != nil {
+		return x.RxTotal
+	}
+	return 0
+}
+
+func (x *PortStats) GetTxPackets() uint64 {
+	if x != nil {
+		return x.TxPackets
+	}
+	return 0
+}
+
+func (x *PortStats) GetTxDropped() uint64 {
+	if x != nil {
+		return x.TxDropped
+	}
+	return 0
+}
+
+func (x *PortStats) GetTxTotal() uint64 {
+	if x != nil {
+		return x.TxTotal
+	}
+	return 0
+}
+
+func (x *PortStats) GetRxBadIpCsum() uint64 {
+	if x != nil {
+		return x.RxBadIpCsum
+	}
+	return 0
+}
+
+func (x *PortStats) GetRxBadL4Csum() uint64 {
+	if x != nil {
+		return x.RxBadL4Csum
+	}
+	return 0
+}
+
+func (x *PortStats) GetRxBadOuterL4Csum() uint64 {
+	if x != nil {
+		return x.RxBadOuterL4Csum
+	}
+	return 0
+}
+
+// forwarding statistics since the last clear
+type Stats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ports []*PortStats `protobuf:"bytes,1,rep,name=ports,proto3" json:"ports,omitempty"`
+	// all ports together, port_id is -1
+	Total *PortStats `protobuf:"bytes,2,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *Stats) Reset() {
+	*x = Stats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Stats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stats) ProtoMessage() {}
+
+func (x *Stats) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stats.ProtoReflect.Descriptor instead.
+func (*Stats) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Stats) GetPorts() []*PortStats {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+func (x *Stats) GetTotal() *PortStats {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+type GetStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{13}
+}
+
+type ClearStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ClearStatsRequest) Reset() {
+	*x = ClearStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClearStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearStatsRequest) ProtoMessage() {}
+
+func (x *ClearStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearStatsRequest.ProtoReflect.Descriptor instead.
+func (*ClearStatsRequest) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{14}
+}
+
+type WatchThroughputRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// sampling interval in milliseconds, 1000 if not set
+	IntervalMs uint32 `protobuf:"varint,1,opt,name=interval_ms,json=intervalMs,proto3" json:"interval_ms,omitempty"`
+}
+
+func (x *WatchThroughputRequest) Reset() {
+	*x = WatchThroughputRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchThroughputRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchThroughputRequest) ProtoMessage() {}
+
+func (x *WatchThroughputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchThroughputRequest.ProtoReflect.Descriptor instead.
+func (*WatchThroughputRequest) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WatchThroughputRequest) GetIntervalMs() uint32 {
+	if x != nil {
+		return x.IntervalMs
+	}
+	return 0
+}
+
+type PortThroughput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PortId int32   `protobuf:"varint,1,opt,name=port_id,json=portId,proto3" json:"port_id,omitempty"`
+	RxPps  float64 `protobuf:"fixed64,2,opt,name=rx_pps,json=rxPps,proto3" json:"rx_pps,omitempty"`
+	TxPps  float64 `protobuf:"fixed64,3,opt,name=tx_pps,json=txPps,proto3" json:"tx_pps,omitempty"`
+	RxBps  float64 `protobuf:"fixed64,4,opt,name=rx_bps,json=rxBps,proto3" json:"rx_bps,omitempty"`
+	TxBps  float64 `protobuf:"fixed64,5,opt,name=tx_bps,json=txBps,proto3" json:"tx_bps,omitempty"`
+	// packets per second missed, in error or without mbuf on rx
+	RxDropPps  float64 `protobuf:"fixed64,6,opt,name=rx_drop_pps,json=rxDropPps,proto3" json:"rx_drop_pps,omitempty"`
+	TxErrorPps float64 `protobuf:"fixed64,7,opt,name=tx_error_pps,json=txErrorPps,proto3" json:"tx_error_pps,omitempty"`
+}
+
+func (x *PortThroughput) Reset() {
+	*x = PortThroughput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PortThroughput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortThroughput) ProtoMessage() {}
+
+func (x *PortThroughput) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortThroughput.ProtoReflect.Descriptor instead.
+func (*PortThroughput) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PortThroughput) GetPortId() int32 {
+	if x != nil {
+		return x.PortId
+	}
+	return 0
+}
+
+func (x *PortThroughput) GetRxPps() float64 {
+	if x != nil {
+		return x.RxPps
+	}
+	return 0
+}
+
+func (x *PortThroughput) GetTxPps() float64 {
+	if x != nil {
+		return x.TxPps
+	}
+	return 0
+}
+
+func (x *PortThroughput) GetRxBps() float64 {
+	if x != nil {
+		return x.RxBps
+	}
+	return 0
+}
+
+func (x *PortThroughput) GetTxBps() float64 {
+	if x != nil {
+		return x.TxBps
+	}
+	return 0
+}
+
+func (x *PortThroughput) GetRxDropPps() float64 {
+	if x != nil {
+		return x.RxDropPps
+	}
+	return 0
+}
+
+func (x *PortThroughput) GetTxErrorPps() float64 {
+	if x != nil {
+		return x.TxErrorPps
+	}
+	return 0
+}
+
+type Throughput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// unix time in milliseconds when the counters were sampled
+	TimestampMs int64 `protobuf:"varint,1,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"`
+	// actual time between this sample and the previous one
+	IntervalMs uint32            `protobuf:"varint,2,opt,name=interval_ms,json=intervalMs,proto3" json:"interval_ms,omitempty"`
+	Ports      []*PortThroughput `protobuf:"bytes,3,rep,name=ports,proto3" json:"ports,omitempty"`
+}
+
+func (x *Throughput) Reset() {
+	*x = Throughput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Throughput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Throughput) ProtoMessage() {}
+
+func (x *Throughput) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Throughput.ProtoReflect.Descriptor instead.
+func (*Throughput) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Throughput) GetTimestampMs() int64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+func (x *Throughput) GetIntervalMs() uint32 {
+	if x != nil {
+		return x.IntervalMs
+	}
+	return 0
+}
+
+func (x *Throughput) GetPorts() []*PortThroughput {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+type Lcore struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// -1 if unknown
+	NumaNode int32 `protobuf:"varint,2,opt,name=numa_node,json=numaNode,proto3" json:"numa_node,omitempty"`
+	// the other hardware threads of the physical core
+	Siblings []int32 `protobuf:"varint,3,rep,packed,name=siblings,proto3" json:"siblings,omitempty"`
+	// on a numa node of the ports
+	Local bool `protobuf:"varint,4,opt,name=local,proto3" json:"local,omitempty"`
+}
+
+func (x *Lcore) Reset() {
+	*x = Lcore{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Lcore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lcore) ProtoMessage() {}
+
+func (x *Lcore) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lcore.ProtoReflect.Descriptor instead.
+func (*Lcore) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Lcore) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Lcore) GetNumaNode() int32 {
+	if x != nil {
+		return x.NumaNode
+	}
+	return 0
+}
+
+func (x *Lcore) GetSiblings() []int32 {
+	if x != nil {
+		return x.Siblings
+	}
+	return nil
+}
+
+func (x *Lcore) GetLocal() bool {
+	if x != nil {
+		return x.Local
+	}
+	return false
+}
+
+// queues, rings and mtu of every port
+type PortConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RxQueues   uint32 `protobuf:"varint,1,opt,name=rx_queues,json=rxQueues,proto3" json:"rx_queues,omitempty"`
+	TxQueues   uint32 `protobuf:"varint,2,opt,name=tx_queues,json=txQueues,proto3" json:"tx_queues,omitempty"`
+	RxRingSize uint32 `protobuf:"varint,3,opt,name=rx_ring_size,json=rxRingSize,proto3" json:"rx_ring_size,omitempty"`
+	TxRingSize uint32 `protobuf:"varint,4,opt,name=tx_ring_size,json=txRingSize,proto3" json:"tx_ring_size,omitempty"`
+	// testpmd sizes its mbuf pools at startup, ConfigurePorts can't change it
+	MbufSize uint32 `protobuf:"varint,5,opt,name=mbuf_size,json=mbufSize,proto3" json:"mbuf_size,omitempty"`
+	Mtu      uint32 `protobuf:"varint,6,opt,name=mtu,proto3" json:"mtu,omitempty"`
+}
+
+func (x *PortConfig) Reset() {
+	*x = PortConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PortConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortConfig) ProtoMessage() {}
+
+func (x *PortConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortConfig.ProtoReflect.Descriptor instead.
+func (*PortConfig) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PortConfig) GetRxQueues() uint32 {
+	if x != nil {
+		return x.RxQueues
+	}
+	return 0
+}
+
+func (x *PortConfig) GetTxQueues() uint32 {
+	if x != nil {
+		return x.TxQueues
+	}
+	return 0
+}
+
+func (x *PortConfig) GetRxRingSize() uint32 {
+	if x != nil {
+		return x.RxRingSize
+	}
+	return 0
+}
+
+func (x *PortConfig) GetTxRingSize() uint32 {
+	if x != nil {
+		return x.TxRingSize
+	}
+	return 0
+}
+
+func (x *PortConfig) GetMbufSize() uint32 {
+	if x != nil {
+		return x.MbufSize
+	}
+	return 0
+}
+
+func (x *PortConfig) GetMtu() uint32 {
+	if x != nil {
+		return x.Mtu
+	}
+	return 0
+}
+
+// a port and the drivers the wrapper moved it between, the drivers are empty for vdev ports
+type PortBinding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PortId int32 `protobuf:"varint,1,opt,name=port_id,json=portId,proto3" json:"port_id,omitempty"`
+	// pci address or vdev name
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// the driver before the wrapper took the port over, restored on shutdown
+	PreviousDriver string `protobuf:"bytes,3,opt,name=previous_driver,json=previousDriver,proto3" json:"previous_driver,omitempty"`
+	Driver         string `protobuf:"bytes,4,opt,name=driver,proto3" json:"driver,omitempty"`
+	// the kernel net device the port had
+	Netdev string `protobuf:"bytes,5,opt,name=netdev,proto3" json:"netdev,omitempty"`
+}
+
+func (x *PortBinding) Reset() {
+	*x = PortBinding{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PortBinding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortBinding) ProtoMessage() {}
+
+func (x *PortBinding) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortBinding.ProtoReflect.Descriptor instead.
+func (*PortBinding) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PortBinding) GetPortId() int32 {
+	if x != nil {
+		return x.PortId
+	}
+	return 0
+}
+
+func (x *PortBinding) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PortBinding) GetPreviousDriver() string {
+	if x != nil {
+		return x.PreviousDriver
+	}
+	return ""
+}
+
+func (x *PortBinding) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *PortBinding) GetNetdev() string {
+	if x != nil {
+		return x.Netdev
+	}
+	return ""
+}
+
+type Status struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Engine  Engine `protobuf:"varint,1,opt,name=engine,proto3,enum=testpmd.v2.Engine" json:"engine,omitempty"`
+	Mode    string `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	Running bool   `protobuf:"varint,3,opt,name=running,proto3" json:"running,omitempty"`
+	// testpmd process
+	Pid        int32                `protobuf:"varint,4,opt,name=pid,proto3" json:"pid,omitempty"`
+	StartTime  *timestamp.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	Uptime     *duration.Duration   `protobuf:"bytes,6,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	FilePrefix string               `protobuf:"bytes,7,opt,name=file_prefix,json=filePrefix,proto3" json:"file_prefix,omitempty"`
+	// testpmd command line, EAL and application options
+	Cmdline string `protobuf:"bytes,8,opt,name=cmdline,proto3" json:"cmdline,omitempty"`
+	// as reported by testpmd or given with -dpdk-version, empty if unknown
+	DpdkVersion string `protobuf:"bytes,9,opt,name=dpdk_version,json=dpdkVersion,proto3" json:"dpdk_version,omitempty"`
+	// the LTS release whose EAL and testpmd options are used, e.g. 20.11
+	DpdkRelease string `protobuf:"bytes,10,opt,name=dpdk_release,json=dpdkRelease,proto3" json:"dpdk_release,omitempty"`
+	TestpmdPath string `protobuf:"bytes,11,opt,name=testpmd_path,json=testpmdPath,proto3" json:"testpmd_path,omitempty"`
+	// local-strict, local-preferred or any
+	CorePolicy string         `protobuf:"bytes,12,opt,name=core_policy,json=corePolicy,proto3" json:"core_policy,omitempty"`
+	MainLcore  *Lcore         `protobuf:"bytes,13,opt,name=main_lcore,json=mainLcore,proto3" json:"main_lcore,omitempty"`
+	PmdLcores  []*Lcore       `protobuf:"bytes,14,rep,name=pmd_lcores,json=pmdLcores,proto3" json:"pmd_lcores,omitempty"`
+	Ports      []*PortBinding `protobuf:"bytes,15,rep,name=ports,proto3" json:"ports,omitempty"`
+	PortConfig *PortConfig    `protobuf:"bytes,16,opt,name=port_config,json=portConfig,proto3" json:"port_config,omitempty"`
+}
+
+func (x *Status) Reset() {
+	*x = Status{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Status) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Status) ProtoMessage() {}
+
+func (x *Status) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Status.ProtoReflect.Descriptor instead.
+func (*Status) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Status) GetEngine() Engine {
+	if x != nil {
+		return x.Engine
+	}
+	return Engine_ENGINE_UNSPECIFIED
+}
+
+func (x *Status) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *Status) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *Status) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *Status) GetStartTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *Status) GetUptime() *duration.Duration {
+	if x != nil {
+		return x.Uptime
+	}
+	return nil
+}
+
+func (x *Status) GetFilePrefix() string {
+	if x != nil {
+		return x.FilePrefix
+	}
+	return ""
+}
+
+func (x *Status) GetCmdline() string {
+	if x != nil {
+		return x.Cmdline
+	}
+	return ""
+}
+
+func (x *Status) GetDpdkVersion() string {
+	if x != nil {
+		return x.DpdkVersion
+	}
+	return ""
+}
+
+func (x *Status) GetDpdkRelease() string {
+	if x != nil {
+		return x.DpdkRelease
+	}
+	return ""
+}
+
+func (x *Status) GetTestpmdPath() string {
+	if x != nil {
+		return x.TestpmdPath
+	}
+	return ""
+}
+
+func (x *Status) GetCorePolicy() string {
+	if x != nil {
+		return x.CorePolicy
+	}
+	return ""
+}
+
+func (x *Status) GetMainLcore() *Lcore {
+	if x != nil {
+		return x.MainLcore
+	}
+	return nil
+}
+
+func (x *Status) GetPmdLcores() []*Lcore {
+	if x != nil {
+		return x.PmdLcores
+	}
+	return nil
+}
+
+func (x *Status) GetPorts() []*PortBinding {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+func (x *Status) GetPortConfig() *PortConfig {
+	if x != nil {
+		return x.PortConfig
+	}
+	return nil
+}
+
+type GetStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_testpmd_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_testpmd_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_v2_testpmd_proto_rawDescGZIP(), []int{22}
+}
+
+var File_v2_testpmd_proto protoreflect.FileDescriptor
+
+var file_v2_testpmd_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x76, 0x32, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x0a, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32, 0x1a, 0x1e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4b, 0x0a, 0x04,
+	0x50, 0x6f, 0x72, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x63, 0x5f,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d,
+	0x61, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x12, 0x0a, 0x10, 0x4c, 0x69, 0x73,
+	0x74, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3b, 0x0a,
+	0x11, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32, 0x2e, 0x50,
+	0x6f, 0x72, 0x74, 0x52, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x22, 0x24, 0x0a, 0x0e, 0x47, 0x65,
+	0x74, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x22, 0x49, 0x0a, 0x08, 0x54, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x27, 0x0a, 0x0f,
+	0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0e, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x75, 0x72, 0x73, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x62, 0x75, 0x72, 0x73, 0x74, 0x22, 0x43, 0x0a, 0x07, 0x50,
+	0x65, 0x65, 0x72, 0x4d, 0x61, 0x63, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12,
+	0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x63, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x22, 0xc4, 0x01, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x63, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x63, 0x6f, 0x72,
+	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x73, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6c, 0x63, 0x6f, 0x72, 0x65, 0x53, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x78, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x72, 0x78, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x19, 0x0a,
+	0x08, 0x72, 0x78, 0x5f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x72, 0x78, 0x51, 0x75, 0x65, 0x75, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x78, 0x5f, 0x70,
+	0x6f, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x74, 0x78, 0x50, 0x6f, 0x72,
+	0x74, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x78, 0x5f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x74, 0x78, 0x51, 0x75, 0x65, 0x75, 0x65, 0x12, 0x19, 0x0a, 0x08,
+	0x70, 0x65, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x63, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x70, 0x65, 0x65, 0x72, 0x4d, 0x61, 0x63, 0x22, 0x9c, 0x02, 0x0a, 0x10, 0x46, 0x6f, 0x72, 0x77,
+	0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x2a, 0x0a, 0x06,
+	0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32, 0x2e, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65,
+	0x52, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x31, 0x0a, 0x09,
+	0x74, 0x78, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32, 0x2e, 0x54, 0x78, 0x50,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x08, 0x74, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12,
+	0x30, 0x0a, 0x09, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x63, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32, 0x2e,
+	0x50, 0x65, 0x65, 0x72, 0x4d, 0x61, 0x63, 0x52, 0x08, 0x70, 0x65, 0x65, 0x72, 0x4d, 0x61, 0x63,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x6e,
+	0x75, 0x6d, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x6e, 0x75, 0x6d, 0x43, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x65, 0x73, 0x74,
+	0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x07, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x22, 0x1c, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x46, 0x6f, 0x72,
+	0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x18, 0x0a, 0x16, 0x53, 0x74, 0x61, 0x72, 0x74, 0x46, 0x6f, 0x72,
+	0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x17,
+	0x0a, 0x15, 0x53, 0x74, 0x6f, 0x70, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xd0, 0x02, 0x0a, 0x09, 0x50, 0x6f, 0x72, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x1d,
+	0x0a, 0x0a, 0x72, 0x78, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x09, 0x72, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x1d, 0x0a,
+	0x0a, 0x72, 0x78, 0x5f, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x72, 0x78, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x12, 0x19, 0x0a, 0x08,
+	0x72, 0x78, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x72, 0x78, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x78, 0x5f, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x78, 0x50,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x78, 0x5f, 0x64, 0x72, 0x6f,
+	0x70, 0x70, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x78, 0x44, 0x72,
+	0x6f, 0x70, 0x70, 0x65, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x78, 0x5f, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x74, 0x78, 0x54, 0x6f, 0x74, 0x61, 0x6c,
+	0x12, 0x23, 0x0a, 0x0e, 0x72, 0x78, 0x5f, 0x62, 0x61, 0x64, 0x5f, 0x69, 0x70, 0x5f, 0x63, 0x73,
+	0x75, 0x6d, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x72, 0x78, 0x42, 0x61, 0x64, 0x49,
+	0x70, 0x43, 0x73, 0x75, 0x6d, 0x12, 0x23, 0x0a, 0x0e, 0x72, 0x78, 0x5f, 0x62, 0x61, 0x64, 0x5f,
+	0x6c, 0x34, 0x5f, 0x63, 0x73, 0x75, 0x6d, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x72,
+	0x78, 0x42, 0x61, 0x64, 0x4c, 0x34, 0x43, 0x73, 0x75, 0x6d, 0x12, 0x2e, 0x0a, 0x14, 0x72, 0x78,
+	0x5f, 0x62, 0x61, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x5f, 0x6c, 0x34, 0x5f, 0x63, 0x73,
+	0x75, 0x6d, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x72, 0x78, 0x42, 0x61, 0x64, 0x4f,
+	0x75, 0x74, 0x65, 0x72, 0x4c, 0x34, 0x43, 0x73, 0x75, 0x6d, 0x22, 0x61, 0x0a, 0x05, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32, 0x2e,
+	0x50, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73,
+	0x12, 0x2b, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32, 0x2e, 0x50, 0x6f, 0x72,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x11, 0x0a,
+	0x0f, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x13, 0x0a, 0x11, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x39, 0x0a, 0x16, 0x57, 0x61, 0x74, 0x63, 0x68, 0x54, 0x68,
+	0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x6d, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4d, 0x73,
+	0x22, 0xc7, 0x01, 0x0a, 0x0e, 0x50, 0x6f, 0x72, 0x74, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68,
+	0x70, 0x75, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06,
+	0x72, 0x78, 0x5f, 0x70, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x72, 0x78,
+	0x50, 0x70, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x74, 0x78, 0x5f, 0x70, 0x70, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x05, 0x74, 0x78, 0x50, 0x70, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x72, 0x78,
+	0x5f, 0x62, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x72, 0x78, 0x42, 0x70,
+	0x73, 0x12, 0x15, 0x0a, 0x06, 0x74, 0x78, 0x5f, 0x62, 0x70, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x05, 0x74, 0x78, 0x42, 0x70, 0x73, 0x12, 0x1e, 0x0a, 0x0b, 0x72, 0x78, 0x5f, 0x64,
+	0x72, 0x6f, 0x70, 0x5f, 0x70, 0x70, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x72,
+	0x78, 0x44, 0x72, 0x6f, 0x70, 0x50, 0x70, 0x73, 0x12, 0x20, 0x0a, 0x0c, 0x74, 0x78, 0x5f, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x5f, 0x70, 0x70, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a,
+	0x74, 0x78, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x50, 0x70, 0x73, 0x22, 0x82, 0x01, 0x0a, 0x0a, 0x54,
+	0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4d, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4d, 0x73, 0x12, 0x30, 0x0a,
+	0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x54, 0x68,
+	0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x52, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x22,
+	0x66, 0x0a, 0x05, 0x4c, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x75, 0x6d, 0x61,
+	0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6e, 0x75, 0x6d,
+	0x61, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x69, 0x62, 0x6c, 0x69, 0x6e, 0x67,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x08, 0x73, 0x69, 0x62, 0x6c, 0x69, 0x6e, 0x67,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x22, 0xb9, 0x01, 0x0a, 0x0a, 0x50, 0x6f, 0x72, 0x74,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x78, 0x5f, 0x71, 0x75, 0x65,
+	0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x72, 0x78, 0x51, 0x75, 0x65,
+	0x75, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x78, 0x5f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x74, 0x78, 0x51, 0x75, 0x65, 0x75, 0x65, 0x73,
+	0x12, 0x20, 0x0a, 0x0c, 0x72, 0x78, 0x5f, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x72, 0x78, 0x52, 0x69, 0x6e, 0x67, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x74, 0x78, 0x5f, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x74, 0x78, 0x52, 0x69, 0x6e, 0x67,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x62, 0x75, 0x66, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d, 0x62, 0x75, 0x66, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x74, 0x75, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03,
+	0x6d, 0x74, 0x75, 0x22, 0x93, 0x01, 0x0a, 0x0b, 0x50, 0x6f, 0x72, 0x74, 0x42, 0x69, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x27, 0x0a, 0x0f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x64, 0x72, 0x69,
+	0x76, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x70, 0x72, 0x65, 0x76, 0x69,
+	0x6f, 0x75, 0x73, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x69,
+	0x76, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65,
+	0x72, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x65, 0x74, 0x64, 0x65, 0x76, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6e, 0x65, 0x74, 0x64, 0x65, 0x76, 0x22, 0xf3, 0x04, 0x0a, 0x06, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x2a, 0x0a, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76,
+	0x32, 0x2e, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x52, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6d, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x10,
+	0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x70, 0x69, 0x64,
+	0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x75,
+	0x70, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12,
+	0x18, 0x0a, 0x07, 0x63, 0x6d, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x63, 0x6d, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x70, 0x64,
+	0x6b, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x70, 0x64, 0x6b, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c,
+	0x64, 0x70, 0x64, 0x6b, 0x5f, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x70, 0x64, 0x6b, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x12,
+	0x21, 0x0a, 0x0c, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x50, 0x61,
+	0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x72, 0x65, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x12, 0x30, 0x0a, 0x0a, 0x6d, 0x61, 0x69, 0x6e, 0x5f, 0x6c, 0x63, 0x6f, 0x72,
+	0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d,
+	0x64, 0x2e, 0x76, 0x32, 0x2e, 0x4c, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x09, 0x6d, 0x61, 0x69, 0x6e,
+	0x4c, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x30, 0x0a, 0x0a, 0x70, 0x6d, 0x64, 0x5f, 0x6c, 0x63, 0x6f,
+	0x72, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x65, 0x73, 0x74,
+	0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32, 0x2e, 0x4c, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x09, 0x70, 0x6d,
+	0x64, 0x4c, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73,
+	0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64,
+	0x2e, 0x76, 0x32, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x42, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52,
+	0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x37, 0x0a, 0x0b, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x65,
+	0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x0a, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22,
+	0x12, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2a, 0xd8, 0x01, 0x0a, 0x06, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x16,
+	0x0a, 0x12, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45,
+	0x5f, 0x49, 0x4f, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f,
+	0x4d, 0x41, 0x43, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f,
+	0x4d, 0x41, 0x43, 0x53, 0x57, 0x41, 0x50, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x45, 0x4e, 0x47,
+	0x49, 0x4e, 0x45, 0x5f, 0x46, 0x4c, 0x4f, 0x57, 0x47, 0x45, 0x4e, 0x10, 0x04, 0x12, 0x11, 0x0a,
+	0x0d, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f, 0x52, 0x58, 0x4f, 0x4e, 0x4c, 0x59, 0x10, 0x05,
+	0x12, 0x11, 0x0a, 0x0d, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f, 0x54, 0x58, 0x4f, 0x4e, 0x4c,
+	0x59, 0x10, 0x06, 0x12, 0x0f, 0x0a, 0x0b, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f, 0x43, 0x53,
+	0x55, 0x4d, 0x10, 0x07, 0x12, 0x13, 0x0a, 0x0f, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f, 0x49,
+	0x43, 0x4d, 0x50, 0x45, 0x43, 0x48, 0x4f, 0x10, 0x08, 0x12, 0x11, 0x0a, 0x0d, 0x45, 0x4e, 0x47,
+	0x49, 0x4e, 0x45, 0x5f, 0x35, 0x54, 0x53, 0x57, 0x41, 0x50, 0x10, 0x09, 0x12, 0x10, 0x0a, 0x0c,
+	0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f, 0x4e, 0x4f, 0x49, 0x53, 0x59, 0x10, 0x0a, 0x32, 0xaf,
+	0x06, 0x0a, 0x07, 0x54, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x12, 0x48, 0x0a, 0x09, 0x4c, 0x69,
+	0x73, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x1c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d,
+	0x64, 0x2e, 0x76, 0x32, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e,
+	0x76, 0x32, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x12,
+	0x1a, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32, 0x2e, 0x47, 0x65, 0x74,
+	0x50, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x74, 0x65,
+	0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x5b, 0x0a,
+	0x13, 0x47, 0x65, 0x74, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x12, 0x26, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76,
+	0x32, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32, 0x2e, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72,
+	0x64, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x54, 0x0a, 0x16, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x12, 0x1c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76,
+	0x32, 0x2e, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x1a, 0x1c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32, 0x2e,
+	0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x12, 0x53, 0x0a, 0x0f, 0x53, 0x74, 0x61, 0x72, 0x74, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x22, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32,
+	0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d,
+	0x64, 0x2e, 0x76, 0x32, 0x2e, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x46, 0x0a, 0x0e, 0x53, 0x74, 0x6f, 0x70, 0x46, 0x6f, 0x72,
+	0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x21, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d,
+	0x64, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x74, 0x65, 0x73,
+	0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x40, 0x0a,
+	0x0e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x12,
+	0x16, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32, 0x2e, 0x50, 0x6f, 0x72,
+	0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x1a, 0x16, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d,
+	0x64, 0x2e, 0x76, 0x32, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
+	0x3a, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1b, 0x2e, 0x74, 0x65,
+	0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70,
+	0x6d, 0x64, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x43, 0x0a, 0x0a, 0x43,
+	0x6c, 0x65, 0x61, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1d, 0x2e, 0x74, 0x65, 0x73, 0x74,
+	0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x12, 0x4f, 0x0a, 0x0f, 0x57, 0x61, 0x74, 0x63, 0x68, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68,
+	0x70, 0x75, 0x74, 0x12, 0x22, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32,
+	0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d,
+	0x64, 0x2e, 0x76, 0x32, 0x2e, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x30,
+	0x01, 0x12, 0x3d, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c,
+	0x2e, 0x74, 0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32, 0x2e, 0x47, 0x65, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x70, 0x6d, 0x64, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x42, 0x48, 0x5a, 0x46, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72,
+	0x65, 0x64, 0x68, 0x61, 0x74, 0x2d, 0x6e, 0x66, 0x76, 0x70, 0x65, 0x2f, 0x63, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x2d, 0x70, 0x65, 0x72, 0x66, 0x2d, 0x74, 0x6f, 0x6f, 0x6c, 0x73,
+	0x2f, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x6f, 0x6e, 0x65, 0x2d, 0x74, 0x65, 0x73, 0x74,
+	0x70, 0x6d, 0x64, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x76, 0x32, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_v2_testpmd_proto_rawDescOnce sync.Once
+	file_v2_testpmd_proto_rawDescData = file_v2_testpmd_proto_rawDesc
+)
+
+func file_v2_testpmd_proto_rawDescGZIP() []byte {
+	file_v2_testpmd_proto_rawDescOnce.Do(func() {
+		file_v2_testpmd_proto_rawDescData = protoimpl.X.CompressGZIP(file_v2_testpmd_proto_rawDescData)
+	})
+	return file_v2_testpmd_proto_rawDescData
+}
+
+var file_v2_testpmd_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_v2_testpmd_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_v2_testpmd_proto_goTypes = []interface{}{
+	(Engine)(0),                        // 0: testpmd.v2.Engine
+	(*Port)(nil),                       // 1: testpmd.v2.Port
+	(*ListPortsRequest)(nil),           // 2: testpmd.v2.ListPortsRequest
+	(*ListPortsResponse)(nil),          // 3: testpmd.v2.ListPortsResponse
+	(*GetPortRequest)(nil),             // 4: testpmd.v2.GetPortRequest
+	(*TxPacket)(nil),                   // 5: testpmd.v2.TxPacket
+	(*PeerMac)(nil),                    // 6: testpmd.v2.PeerMac
+	(*Stream)(nil),                     // 7: testpmd.v2.Stream
+	(*ForwardingConfig)(nil),           // 8: testpmd.v2.ForwardingConfig
+	(*GetForwardingConfigRequest)(nil), // 9: testpmd.v2.GetForwardingConfigRequest
+	(*StartForwardingRequest)(nil),     // 10: testpmd.v2.StartForwardingRequest
+	(*StopForwardingRequest)(nil),      // 11: testpmd.v2.StopForwardingRequest
+	(*PortStats)(nil),                  // 12: testpmd.v2.PortStats
+	(*Stats)(nil),                      // 13: testpmd.v2.Stats
+	(*GetStatsRequest)(nil),            // 14: testpmd.v2.GetStatsRequest
+	(*ClearStatsRequest)(nil),          // 15: testpmd.v2.ClearStatsRequest
+	(*WatchThroughputRequest)(nil),     // 16: testpmd.v2.WatchThroughputRequest
+	(*PortThroughput)(nil),             // 17: testpmd.v2.PortThroughput
+	(*Throughput)(nil),                 // 18: testpmd.v2.Throughput
+	(*Lcore)(nil),                      // 19: testpmd.v2.Lcore
+	(*PortConfig)(nil),                 // 20: testpmd.v2.PortConfig
+	(*PortBinding)(nil),                // 21: testpmd.v2.PortBinding
+	(*Status)(nil),                     // 22: testpmd.v2.Status
+	(*GetStatusRequest)(nil),           // 23: testpmd.v2.GetStatusRequest
+	(*timestamp.Timestamp)(nil),        // 24: google.protobuf.Timestamp
+	(*duration.Duration)(nil),          // 25: google.protobuf.Duration
+	(*empty.Empty)(nil),                // 26: google.protobuf.Empty
+}
+var file_v2_testpmd_proto_depIdxs = []int32{
+	1,  // 0: testpmd.v2.ListPortsResponse.ports:type_name -> testpmd.v2.Port
+	0,  // 1: testpmd.v2.ForwardingConfig.engine:type_name -> testpmd.v2.Engine
+	5,  // 2: testpmd.v2.ForwardingConfig.tx_packet:type_name -> testpmd.v2.TxPacket
+	6,  // 3: testpmd.v2.ForwardingConfig.peer_macs:type_name -> testpmd.v2.PeerMac
+	7,  // 4: testpmd.v2.ForwardingConfig.streams:type_name -> testpmd.v2.Stream
+	12, // 5: testpmd.v2.Stats.ports:type_name -> testpmd.v2.PortStats
+	12, // 6: testpmd.v2.Stats.total:type_name -> testpmd.v2.PortStats
+	17, // 7: testpmd.v2.Throughput.ports:type_name -> testpmd.v2.PortThroughput
+	0,  // 8: testpmd.v2.Status.engine:type_name -> testpmd.v2.Engine
+	24, // 9: testpmd.v2.Status.start_time:type_name -> google.protobuf.Timestamp
+	25, // 10: testpmd.v2.Status.uptime:type_name -> google.protobuf.Duration
+	19, // 11: testpmd.v2.Status.main_lcore:type_name -> testpmd.v2.Lcore
+	19, // 12: testpmd.v2.Status.pmd_lcores:type_name -> testpmd.v2.Lcore
+	21, // 13: testpmd.v2.Status.ports:type_name -> testpmd.v2.PortBinding
+	20, // 14: testpmd.v2.Status.port_config:type_name -> testpmd.v2.PortConfig
+	2,  // 15: testpmd.v2.Testpmd.ListPorts:input_type -> testpmd.v2.ListPortsRequest
+	4,  // 16: testpmd.v2.Testpmd.GetPort:input_type -> testpmd.v2.GetPortRequest
+	9,  // 17: testpmd.v2.Testpmd.GetForwardingConfig:input_type -> testpmd.v2.GetForwardingConfigRequest
+	8,  // 18: testpmd.v2.Testpmd.UpdateForwardingConfig:input_type -> testpmd.v2.ForwardingConfig
+	10, // 19: testpmd.v2.Testpmd.StartForwarding:input_type -> testpmd.v2.StartForwardingRequest
+	11, // 20: testpmd.v2.Testpmd.StopForwarding:input_type -> testpmd.v2.StopForwardingRequest
+	20, // 21: testpmd.v2.Testpmd.ConfigurePorts:input_type -> testpmd.v2.PortConfig
+	14, // 22: testpmd.v2.Testpmd.GetStats:input_type -> testpmd.v2.GetStatsRequest
+	15, // 23: testpmd.v2.Testpmd.ClearStats:input_type -> testpmd.v2.ClearStatsRequest
+	16, // 24: testpmd.v2.Testpmd.WatchThroughput:input_type -> testpmd.v2.WatchThroughputRequest
+	23, // 25: testpmd.v2.Testpmd.GetStatus:input_type -> testpmd.v2.GetStatusRequest
+	3,  // 26: testpmd.v2.Testpmd.ListPorts:output_type -> testpmd.v2.ListPortsResponse
+	1,  // 27: testpmd.v2.Testpmd.GetPort:output_type -> testpmd.v2.Port
+	8,  // 28: testpmd.v2.Testpmd.GetForwardingConfig:output_type -> testpmd.v2.ForwardingConfig
+	8,  // 29: testpmd.v2.Testpmd.UpdateForwardingConfig:output_type -> testpmd.v2.ForwardingConfig
+	8,  // 30: testpmd.v2.Testpmd.StartForwarding:output_type -> testpmd.v2.ForwardingConfig
+	13, // 31: testpmd.v2.Testpmd.StopForwarding:output_type -> testpmd.v2.Stats
+	20, // 32: testpmd.v2.Testpmd.ConfigurePorts:output_type -> testpmd.v2.PortConfig
+	13, // 33: testpmd.v2.Testpmd.GetStats:output_type -> testpmd.v2.Stats
+	26, // 34: testpmd.v2.Testpmd.ClearStats:output_type -> google.protobuf.Empty
+	18, // 35: testpmd.v2.Testpmd.WatchThroughput:output_type -> testpmd.v2.Throughput
+	22, // 36: testpmd.v2.Testpmd.GetStatus:output_type -> testpmd.v2.Status
+	26, // [26:37] is the sub-list for method output_type
+	15, // [15:26] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_v2_testpmd_proto_init() }
+func file_v2_testpmd_proto_init() {
+	if File_v2_testpmd_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_v2_testpmd_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Port); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPortsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPortsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPortRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxPacket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerMac); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Stream); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ForwardingConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetForwardingConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartForwardingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopForwardingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PortStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Stats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClearStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchThroughputRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PortThroughput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Throughput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Lcore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PortConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PortBinding); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Status); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_testpmd_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_v2_testpmd_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_v2_testpmd_proto_goTypes,
+		DependencyIndexes: file_v2_testpmd_proto_depIdxs,
+		EnumInfos:         file_v2_testpmd_proto_enumTypes,
+		MessageInfos:      file_v2_testpmd_proto_msgTypes,
+	}.Build()
+	File_v2_testpmd_proto = out.File
+	file_v2_testpmd_proto_rawDesc = nil
+	file_v2_testpmd_proto_goTypes = nil
+	file_v2_testpmd_proto_depIdxs = nil
+}
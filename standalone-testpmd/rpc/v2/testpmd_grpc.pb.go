@@ -0,0 +1,498 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package v2
+
+import (
+	context "context"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// TestpmdClient is the client API for Testpmd service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TestpmdClient interface {
+	ListPorts(ctx context.Context, in *ListPortsRequest, opts ...grpc.CallOption) (*ListPortsResponse, error)
+	GetPort(ctx context.Context, in *GetPortRequest, opts ...grpc.CallOption) (*Port, error)
+	GetForwardingConfig(ctx context.Context, in *GetForwardingConfigRequest, opts ...grpc.CallOption) (*ForwardingConfig, error)
+	// stops forwarding, applies the engine and its parameters and starts forwarding again
+	UpdateForwardingConfig(ctx context.Context, in *ForwardingConfig, opts ...grpc.CallOption) (*ForwardingConfig, error)
+	// starts forwarding in the current mode, FAILED_PRECONDITION if testpmd refuses, like with stopped ports
+	StartForwarding(ctx context.Context, in *StartForwardingRequest, opts ...grpc.CallOption) (*ForwardingConfig, error)
+	// stops forwarding, the mode is kept, and returns the statistics of the run
+	StopForwarding(ctx context.Context, in *StopForwardingRequest, opts ...grpc.CallOption) (*Stats, error)
+	// stops the ports, applies the non zero settings and starts the ports and forwarding again,
+	// the previous settings are put back if testpmd rejects one, INTERNAL with the reason
+	// PORT_SETTINGS_UNKNOWN if that fails too and the ports may be down
+	ConfigurePorts(ctx context.Context, in *PortConfig, opts ...grpc.CallOption) (*PortConfig, error)
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*Stats, error)
+	ClearStats(ctx context.Context, in *ClearStatsRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	WatchThroughput(ctx context.Context, in *WatchThroughputRequest, opts ...grpc.CallOption) (Testpmd_WatchThroughputClient, error)
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*Status, error)
+}
+
+type testpmdClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTestpmdClient(cc grpc.ClientConnInterface) TestpmdClient {
+	return &testpmdClient{cc}
+}
+
+func (c *testpmdClient) ListPorts(ctx context.Context, in *ListPortsRequest, opts ...grpc.CallOption) (*ListPortsResponse, error) {
+	out := new(ListPortsResponse)
+	err := c.cc.Invoke(ctx, "/testpmd.v2.Testpmd/ListPorts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testpmdClient) GetPort(ctx context.Context, in *GetPortRequest, opts ...grpc.CallOption) (*Port, error) {
+	out := new(Port)
+	err := c.cc.Invoke(ctx, "/testpmd.v2.Testpmd/GetPort", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testpmdClient) GetForwardingConfig(ctx context.Context, in *GetForwardingConfigRequest, opts ...grpc.CallOption) (*ForwardingConfig, error) {
+	out := new(ForwardingConfig)
+	err := c.cc.Invoke(ctx, "/testpmd.v2.Testpmd/GetForwardingConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testpmdClient) UpdateForwardingConfig(ctx context.Context, in *ForwardingConfig, opts ...grpc.CallOption) (*ForwardingConfig, error) {
+	out := new(ForwardingConfig)
+	err := c.cc.Invoke(ctx, "/testpmd.v2.Testpmd/UpdateForwardingConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testpmdClient) StartForwarding(ctx context.Context, in *StartForwardingRequest, opts ...grpc.CallOption) (*ForwardingConfig, error) {
+	out := new(ForwardingConfig)
+	err := c.cc.Invoke(ctx, "/testpmd.v2.Testpmd/StartForwarding", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testpmdClient) StopForwarding(ctx context.Context, in *StopForwardingRequest, opts ...grpc.CallOption) (*Stats, error) {
+	out := new(Stats)
+	err := c.cc.Invoke(ctx, "/testpmd.v2.Testpmd/StopForwarding", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testpmdClient) ConfigurePorts(ctx context.Context, in *PortConfig, opts ...grpc.CallOption) (*PortConfig, error) {
+	out := new(PortConfig)
+	err := c.cc.Invoke(ctx, "/testpmd.v2.Testpmd/ConfigurePorts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testpmdClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*Stats, error) {
+	out := new(Stats)
+	err := c.cc.Invoke(ctx, "/testpmd.v2.Testpmd/GetStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testpmdClient) ClearStats(ctx context.Context, in *ClearStatsRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/testpmd.v2.Testpmd/ClearStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testpmdClient) WatchThroughput(ctx context.Context, in *WatchThroughputRequest, opts ...grpc.CallOption) (Testpmd_WatchThroughputClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Testpmd_serviceDesc.Streams[0], "/testpmd.v2.Testpmd/WatchThroughput", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &testpmdWatchThroughputClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Testpmd_WatchThroughputClient interface {
+	Recv() (*Throughput, error)
+	grpc.ClientStream
+}
+
+type testpmdWatchThroughputClient struct {
+	grpc.ClientStream
+}
+
+func (x *testpmdWatchThroughputClient) Recv() (*Throughput, error) {
+	m := new(Throughput)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *testpmdClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/testpmd.v2.Testpmd/GetStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TestpmdServer is the server API for Testpmd service.
+// All implementations must embed UnimplementedTestpmdServer
+// for forward compatibility
+type TestpmdServer interface {
+	ListPorts(context.Context, *ListPortsRequest) (*ListPortsResponse, error)
+	GetPort(context.Context, *GetPortRequest) (*Port, error)
+	GetForwardingConfig(context.Context, *GetForwardingConfigRequest) (*ForwardingConfig, error)
+	// stops forwarding, applies the engine and its parameters and starts forwarding again
+	UpdateForwardingConfig(context.Context, *ForwardingConfig) (*ForwardingConfig, error)
+	// starts forwarding in the current mode, FAILED_PRECONDITION if testpmd refuses, like with stopped ports
+	StartForwarding(context.Context, *StartForwardingRequest) (*ForwardingConfig, error)
+	// stops forwarding, the mode is kept, and returns the statistics of the run
+	StopForwarding(context.Context, *StopForwardingRequest) (*Stats, error)
+	// stops the ports, applies the non zero settings and starts the ports and forwarding again,
+	// the previous settings are put back if testpmd rejects one, INTERNAL with the reason
+	// PORT_SETTINGS_UNKNOWN if that fails too and the ports may be down
+	ConfigurePorts(context.Context, *PortConfig) (*PortConfig, error)
+	GetStats(context.Context, *GetStatsRequest) (*Stats, error)
+	ClearStats(context.Context, *ClearStatsRequest) (*empty.Empty, error)
+	WatchThroughput(*WatchThroughputRequest, Testpmd_WatchThroughputServer) error
+	GetStatus(context.Context, *GetStatusRequest) (*Status, error)
+	mustEmbedUnimplementedTestpmdServer()
+}
+
+// UnimplementedTestpmdServer must be embedded to have forward compatible implementations.
+type UnimplementedTestpmdServer struct {
+}
+
+func (UnimplementedTestpmdServer) ListPorts(context.Context, *ListPortsRequest) (*ListPortsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPorts not implemented")
+}
+func (UnimplementedTestpmdServer) GetPort(context.Context, *GetPortRequest) (*Port, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPort not implemented")
+}
+func (UnimplementedTestpmdServer) GetForwardingConfig(context.Context, *GetForwardingConfigRequest) (*ForwardingConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetForwardingConfig not implemented")
+}
+func (UnimplementedTestpmdServer) UpdateForwardingConfig(context.Context, *ForwardingConfig) (*ForwardingConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateForwardingConfig not implemented")
+}
+func (UnimplementedTestpmdServer) StartForwarding(context.Context, *StartForwardingRequest) (*ForwardingConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartForwarding not implemented")
+}
+func (UnimplementedTestpmdServer) StopForwarding(context.Context, *StopForwardingRequest) (*Stats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopForwarding not implemented")
+}
+func (UnimplementedTestpmdServer) ConfigurePorts(context.Context, *PortConfig) (*PortConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigurePorts not implemented")
+}
+func (UnimplementedTestpmdServer) GetStats(context.Context, *GetStatsRequest) (*Stats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedTestpmdServer) ClearStats(context.Context, *ClearStatsRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearStats not implemented")
+}
+func (UnimplementedTestpmdServer) WatchThroughput(*WatchThroughputRequest, Testpmd_WatchThroughputServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchThroughput not implemented")
+}
+func (UnimplementedTestpmdServer) GetStatus(context.Context, *GetStatusRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedTestpmdServer) mustEmbedUnimplementedTestpmdServer() {}
+
+// UnsafeTestpmdServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TestpmdServer will
+// result in compilation errors.
+type UnsafeTestpmdServer interface {
+	mustEmbedUnimplementedTestpmdServer()
+}
+
+func RegisterTestpmdServer(s grpc.ServiceRegistrar, srv TestpmdServer) {
+	s.RegisterService(&_Testpmd_serviceDesc, srv)
+}
+
+func _Testpmd_ListPorts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPortsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestpmdServer).ListPorts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/testpmd.v2.Testpmd/ListPorts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestpmdServer).ListPorts(ctx, req.(*ListPortsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testpmd_GetPort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestpmdServer).GetPort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/testpmd.v2.Testpmd/GetPort",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestpmdServer).GetPort(ctx, req.(*GetPortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testpmd_GetForwardingConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetForwardingConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestpmdServer).GetForwardingConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/testpmd.v2.Testpmd/GetForwardingConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestpmdServer).GetForwardingConfig(ctx, req.(*GetForwardingConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testpmd_UpdateForwardingConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForwardingConfig)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestpmdServer).UpdateForwardingConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/testpmd.v2.Testpmd/UpdateForwardingConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestpmdServer).UpdateForwardingConfig(ctx, req.(*ForwardingConfig))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testpmd_StartForwarding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartForwardingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestpmdServer).StartForwarding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/testpmd.v2.Testpmd/StartForwarding",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestpmdServer).StartForwarding(ctx, req.(*StartForwardingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testpmd_StopForwarding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopForwardingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestpmdServer).StopForwarding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/testpmd.v2.Testpmd/StopForwarding",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestpmdServer).StopForwarding(ctx, req.(*StopForwardingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testpmd_ConfigurePorts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PortConfig)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestpmdServer).ConfigurePorts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/testpmd.v2.Testpmd/ConfigurePorts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestpmdServer).ConfigurePorts(ctx, req.(*PortConfig))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testpmd_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestpmdServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/testpmd.v2.Testpmd/GetStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestpmdServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testpmd_ClearStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestpmdServer).ClearStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/testpmd.v2.Testpmd/ClearStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestpmdServer).ClearStats(ctx, req.(*ClearStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testpmd_WatchThroughput_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchThroughputRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TestpmdServer).WatchThroughput(m, &testpmdWatchThroughputServer{stream})
+}
+
+type Testpmd_WatchThroughputServer interface {
+	Send(*Throughput) error
+	grpc.ServerStream
+}
+
+type testpmdWatchThroughputServer struct {
+	grpc.ServerStream
+}
+
+func (x *testpmdWatchThroughputServer) Send(m *Throughput) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Testpmd_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestpmdServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/testpmd.v2.Testpmd/GetStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestpmdServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Testpmd_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "testpmd.v2.Testpmd",
+	HandlerType: (*TestpmdServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListPorts",
+			Handler:    _Testpmd_ListPorts_Handler,
+		},
+		{
+			MethodName: "GetPort",
+			Handler:    _Testpmd_GetPort_Handler,
+		},
+		{
+			MethodName: "GetForwardingConfig",
+			Handler:    _Testpmd_GetForwardingConfig_Handler,
+		},
+		{
+			MethodName: "UpdateForwardingConfig",
+			Handler:    _Testpmd_UpdateForwardingConfig_Handler,
+		},
+		{
+			MethodName: "StartForwarding",
+			Handler:    _Testpmd_StartForwarding_Handler,
+		},
+		{
+			MethodName: "StopForwarding",
+			Handler:    _Testpmd_StopForwarding_Handler,
+		},
+		{
+			MethodName: "ConfigurePorts",
+			Handler:    _Testpmd_ConfigurePorts_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _Testpmd_GetStats_Handler,
+		},
+		{
+			MethodName: "ClearStats",
+			Handler:    _Testpmd_ClearStats_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _Testpmd_GetStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchThroughput",
+			Handler:       _Testpmd_WatchThroughput_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "v2/testpmd.proto",
+}